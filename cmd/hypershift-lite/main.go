@@ -11,7 +11,10 @@ import (
 	"github.com/spf13/cobra"
 
 	hyperliteapi "github.com/openshift-hive/hypershiftlite/pkg/api"
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
 	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservicepool"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/storageversion"
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
@@ -30,33 +33,84 @@ func main() {
 	}
 }
 
+type options struct {
+	webhookPort    int
+	webhookCertDir string
+}
+
 func HypershiftLiteCommand() *cobra.Command {
+	opts := &options{}
 	cmd := &cobra.Command{
 		Use: "hypershift-lite",
-		Run: runHypershiftLite,
+		Run: func(cmd *cobra.Command, args []string) {
+			runHypershiftLite(opts)
+		},
 	}
+	cmd.Flags().IntVar(&opts.webhookPort, "webhook-port", 9443, "Port the webhook server listens on")
+	cmd.Flags().StringVar(&opts.webhookCertDir, "webhook-cert-dir", "/var/run/secrets/serving-cert", "Directory holding the tls.crt and tls.key serving certificate of the webhook server")
+	cmd.AddCommand(PauseCommand(), ResumeCommand())
 	return cmd
 }
 
-func runHypershiftLite(cmd *cobra.Command, args []string) {
+func runHypershiftLite(opts *options) {
 	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-		Scheme: hyperliteapi.Scheme,
+		Scheme:  hyperliteapi.Scheme,
+		Port:    opts.webhookPort,
+		CertDir: opts.webhookCertDir,
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
+	// Serves the conversion webhook of KubernetesService
+	if err := ctrl.NewWebhookManagedBy(mgr).For(&hyperlitev1.KubernetesService{}).Complete(); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "KubernetesService")
+		os.Exit(1)
+	}
+
+	if err := (&kubeservice.KubernetesServiceWebhook{
+		Client: mgr.GetClient(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "KubernetesService")
+		os.Exit(1)
+	}
+
+	if err := (&storageversion.Migrator{
+		Client: mgr.GetClient(),
+		Reader: mgr.GetAPIReader(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create storage version migrator")
+		os.Exit(1)
+	}
+
 	if err := (&kubeservice.KubernetesServiceReconciler{
 		Client: mgr.GetClient(),
 		Config: mgr.GetConfig(),
+		Reader: mgr.GetAPIReader(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "hosted-control-plane")
 		os.Exit(1)
 	}
 
+	if err := (&kubeservicepool.PoolReconciler{
+		Client: mgr.GetClient(),
+		Reader: mgr.GetAPIReader(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "kubernetesservicepool")
+		os.Exit(1)
+	}
+
+	if err := (&kubeservicepool.ClaimReconciler{
+		Client: mgr.GetClient(),
+		Reader: mgr.GetAPIReader(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "kubernetesserviceclaim")
+		os.Exit(1)
+	}
+
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		setupLog.Error(err, "problem running manager")
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hyperliteapi "github.com/openshift-hive/hypershiftlite/pkg/api"
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/ks"
+)
+
+type pauseOptions struct {
+	namespace string
+	duration  time.Duration
+}
+
+// PauseCommand pauses reconciliation of a KubernetesService by setting its
+// paused-until annotation, so that its control plane can be changed by hand.
+func PauseCommand() *cobra.Command {
+	opts := &pauseOptions{}
+	cmd := &cobra.Command{
+		Use:   "pause NAME",
+		Short: "Pause reconciliation of a KubernetesService",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pausedUntil := "true"
+			if opts.duration > 0 {
+				pausedUntil = time.Now().Add(opts.duration).UTC().Format(time.RFC3339)
+			}
+			if err := setPausedUntilAnnotation(context.Background(), opts.namespace, args[0], &pausedUntil); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Paused reconciliation of kubernetesservice %s/%s until %s\n", opts.namespace, args[0], pausedUntil)
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&opts.namespace, "namespace", "n", "default", "Namespace of the KubernetesService")
+	cmd.Flags().DurationVar(&opts.duration, "for", 0, "How long to pause reconciliation for. Reconciliation is paused until resumed when not set")
+	return cmd
+}
+
+// ResumeCommand resumes reconciliation of a KubernetesService paused with the
+// pause command.
+func ResumeCommand() *cobra.Command {
+	opts := &pauseOptions{}
+	cmd := &cobra.Command{
+		Use:   "resume NAME",
+		Short: "Resume reconciliation of a KubernetesService paused with the pause command",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := setPausedUntilAnnotation(context.Background(), opts.namespace, args[0], nil); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Resumed reconciliation of kubernetesservice %s/%s\n", opts.namespace, args[0])
+			return nil
+		},
+	}
+	cmd.Flags().StringVarP(&opts.namespace, "namespace", "n", "default", "Namespace of the KubernetesService")
+	return cmd
+}
+
+// setPausedUntilAnnotation sets the paused-until annotation of a
+// KubernetesService, or removes it when the value is nil.
+func setPausedUntilAnnotation(ctx context.Context, namespace, name string, value *string) error {
+	c, err := client.New(ctrl.GetConfigOrDie(), client.Options{Scheme: hyperliteapi.Scheme})
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": map[string]*string{
+				ks.PausedUntilAnnotation: value,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	kubeSvc := &hyperlitev1.KubernetesService{}
+	kubeSvc.Namespace = namespace
+	kubeSvc.Name = name
+	if err := c.Patch(ctx, kubeSvc, client.RawPatch(types.MergePatchType, patch)); err != nil {
+		return fmt.Errorf("failed to update kubernetesservice %s/%s: %w", namespace, name, err)
+	}
+	return nil
+}
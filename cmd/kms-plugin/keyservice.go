@@ -0,0 +1,266 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"path"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+const (
+	encryptPath = "/v1/encrypt"
+	decryptPath = "/v1/decrypt"
+
+	keyServiceTimeout = 10 * time.Second
+	maxPayloadSize    = 1 << 20
+)
+
+// keyServicePayload is the body of the requests to and the responses of the
+// key service.
+type keyServicePayload struct {
+	Data []byte `json:"data"`
+}
+
+type keyServiceOptions struct {
+	listen      string
+	keyFile     string
+	tokenFile   string
+	tlsCertFile string
+	tlsKeyFile  string
+}
+
+// KeyServiceCommand returns a key service that wraps data encryption keys with
+// a key encryption key that never leaves the host it runs on. KMS plugins in
+// the control planes call it over HTTP.
+func KeyServiceCommand() *cobra.Command {
+	opts := keyServiceOptions{}
+	cmd := &cobra.Command{
+		Use:   "key-service",
+		Short: "Serve the key encryption key used by the reference KMS plugin",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runKeyService(opts)
+		},
+	}
+	cmd.Flags().StringVar(&opts.listen, "listen", ":8443", "Address to serve the key service on")
+	cmd.Flags().StringVar(&opts.keyFile, "key-file", "", "File holding the base64 encoded 32 byte key encryption key")
+	cmd.Flags().StringVar(&opts.tokenFile, "token-file", "", "File holding the bearer token KMS plugins must present")
+	cmd.Flags().StringVar(&opts.tlsCertFile, "tls-cert-file", "", "File holding the serving certificate. The key service serves plain HTTP when not set")
+	cmd.Flags().StringVar(&opts.tlsKeyFile, "tls-key-file", "", "File holding the key of the serving certificate")
+	return cmd
+}
+
+func runKeyService(opts keyServiceOptions) error {
+	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
+	if opts.keyFile == "" {
+		return fmt.Errorf("a key file is required")
+	}
+	aead, err := loadKey(opts.keyFile)
+	if err != nil {
+		return err
+	}
+	var token string
+	if opts.tokenFile != "" {
+		if token, err = readToken(opts.tokenFile); err != nil {
+			return err
+		}
+	}
+	mux := http.NewServeMux()
+	mux.Handle(encryptPath, &keyServiceHandler{token: token, transform: func(data []byte) ([]byte, error) {
+		return encrypt(aead, data)
+	}})
+	mux.Handle(decryptPath, &keyServiceHandler{token: token, transform: func(data []byte) ([]byte, error) {
+		return decrypt(aead, data)
+	}})
+	server := &http.Server{Addr: opts.listen, Handler: mux}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-signals
+		ctx, cancel := context.WithTimeout(context.Background(), keyServiceTimeout)
+		defer cancel()
+		server.Shutdown(ctx)
+	}()
+	log.Info("serving key service", "address", opts.listen)
+	if opts.tlsCertFile != "" {
+		err = server.ListenAndServeTLS(opts.tlsCertFile, opts.tlsKeyFile)
+	} else {
+		err = server.ListenAndServe()
+	}
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
+}
+
+// keyServiceHandler authenticates requests to the key service and encrypts or
+// decrypts their data.
+type keyServiceHandler struct {
+	token     string
+	transform func([]byte) ([]byte, error)
+}
+
+func (h *keyServiceHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if h.token != "" {
+		presented := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(presented), []byte(h.token)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+	}
+	payload := &keyServicePayload{}
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxPayloadSize)).Decode(payload); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+		return
+	}
+	data, err := h.transform(payload.Data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(&keyServicePayload{Data: data}); err != nil {
+		log.Error(err, "failed to write response")
+	}
+}
+
+// keyServiceClient calls the key service on behalf of the KMS plugin.
+type keyServiceClient struct {
+	url    *url.URL
+	token  string
+	client *http.Client
+}
+
+func newKeyServiceClient(keyServiceURL, tokenFile, caFile string) (*keyServiceClient, error) {
+	u, err := url.Parse(keyServiceURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key service URL: %w", err)
+	}
+	c := &keyServiceClient{
+		url:    u,
+		client: &http.Client{Timeout: keyServiceTimeout},
+	}
+	if tokenFile != "" {
+		if c.token, err = readToken(tokenFile); err != nil {
+			return nil, err
+		}
+	}
+	if caFile != "" {
+		ca, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificates found in CA file %s", caFile)
+		}
+		c.client.Transport = &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}}
+	}
+	return c, nil
+}
+
+// call sends data to the given endpoint of the key service and returns the
+// data it responds with.
+func (c *keyServiceClient) call(ctx context.Context, endpoint string, data []byte) ([]byte, error) {
+	body, err := json.Marshal(&keyServicePayload{Data: data})
+	if err != nil {
+		return nil, err
+	}
+	u := *c.url
+	u.Path = path.Join(u.Path, endpoint)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call key service: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		message, _ := ioutil.ReadAll(io.LimitReader(resp.Body, maxPayloadSize))
+		return nil, fmt.Errorf("key service returned %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+	payload := &keyServicePayload{}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxPayloadSize)).Decode(payload); err != nil {
+		return nil, fmt.Errorf("invalid key service response: %w", err)
+	}
+	return payload.Data, nil
+}
+
+func readToken(tokenFile string) (string, error) {
+	token, err := ioutil.ReadFile(tokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token file: %w", err)
+	}
+	return strings.TrimSpace(string(token)), nil
+}
+
+func loadKey(keyFile string) (cipher.AEAD, error) {
+	encoded, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encoded)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode key: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("key must be 32 bytes, got %d", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encrypt encrypts data with AES-GCM, prefixing the ciphertext with its nonce.
+func encrypt(aead cipher.AEAD, plain []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, plain, nil), nil
+}
+
+func decrypt(aead cipher.AEAD, data []byte) ([]byte, error) {
+	nonceSize := aead.NonceSize()
+	if len(data) < nonceSize {
+		return nil, fmt.Errorf("ciphertext is too short")
+	}
+	plain, err := aead.Open(nil, data[:nonceSize], data[nonceSize:], nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+	return plain, nil
+}
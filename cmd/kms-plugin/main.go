@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	kmsapi "k8s.io/apiserver/pkg/storage/value/encrypt/envelope/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+const (
+	kmsAPIVersion  = "v1beta1"
+	runtimeName    = "hypershiftlite-kms-plugin"
+	runtimeVersion = "0.1.0"
+)
+
+var log = ctrl.Log.WithName("kms-plugin")
+
+type options struct {
+	socket     string
+	keyService string
+	tokenFile  string
+	caFile     string
+}
+
+func main() {
+	cmd := KMSPluginCommand()
+	cmd.AddCommand(KeyServiceCommand())
+	if err := cmd.Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+}
+
+// KMSPluginCommand returns a reference KMS plugin that has the data encryption
+// keys of the API server wrapped by a key service running outside of the
+// control plane, such as the one started by the key-service command, so that
+// envelope encryption can be used without a cloud KMS.
+func KMSPluginCommand() *cobra.Command {
+	opts := options{}
+	cmd := &cobra.Command{
+		Use:   "kms-plugin",
+		Short: "Reference KMS plugin that wraps data encryption keys with an external key service",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(opts)
+		},
+	}
+	cmd.Flags().StringVar(&opts.socket, "socket", os.Getenv("KMS_PLUGIN_SOCKET"), "Path of the unix socket to serve the KMS API on")
+	cmd.Flags().StringVar(&opts.keyService, "key-service", "", "URL of the key service that wraps data encryption keys")
+	cmd.Flags().StringVar(&opts.tokenFile, "token-file", "", "File holding the bearer token to authenticate to the key service with")
+	cmd.Flags().StringVar(&opts.caFile, "ca-file", "", "File holding the CA bundle to verify the key service with")
+	return cmd
+}
+
+func run(opts options) error {
+	ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
+	if opts.socket == "" {
+		return fmt.Errorf("a socket path is required")
+	}
+	if opts.keyService == "" {
+		return fmt.Errorf("a key service URL is required")
+	}
+	keyService, err := newKeyServiceClient(opts.keyService, opts.tokenFile, opts.caFile)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(opts.socket); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove stale socket: %w", err)
+	}
+	listener, err := net.Listen("unix", opts.socket)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", opts.socket, err)
+	}
+	server := grpc.NewServer()
+	kmsapi.RegisterKeyManagementServiceServer(server, &keyManagementService{keyService: keyService})
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-signals
+		server.GracefulStop()
+	}()
+	log.Info("serving KMS API", "socket", opts.socket, "keyService", opts.keyService)
+	return server.Serve(listener)
+}
+
+// keyManagementService serves the KMS API by forwarding data encryption keys
+// to the key service.
+type keyManagementService struct {
+	keyService *keyServiceClient
+}
+
+func (s *keyManagementService) Version(ctx context.Context, req *kmsapi.VersionRequest) (*kmsapi.VersionResponse, error) {
+	return &kmsapi.VersionResponse{
+		Version:        kmsAPIVersion,
+		RuntimeName:    runtimeName,
+		RuntimeVersion: runtimeVersion,
+	}, nil
+}
+
+func (s *keyManagementService) Encrypt(ctx context.Context, req *kmsapi.EncryptRequest) (*kmsapi.EncryptResponse, error) {
+	cipher, err := s.keyService.call(ctx, encryptPath, req.Plain)
+	if err != nil {
+		return nil, err
+	}
+	return &kmsapi.EncryptResponse{Cipher: cipher}, nil
+}
+
+func (s *keyManagementService) Decrypt(ctx context.Context, req *kmsapi.DecryptRequest) (*kmsapi.DecryptResponse, error) {
+	plain, err := s.keyService.call(ctx, decryptPath, req.Cipher)
+	if err != nil {
+		return nil, err
+	}
+	return &kmsapi.DecryptResponse{Plain: plain}, nil
+}
@@ -1,10 +1,13 @@
 package api
 
 import (
+	routev1 "github.com/openshift/api/route/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 
-	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1alpha1"
+	hyperlitev1alpha1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1alpha1"
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
 	etcdv1 "github.com/openshift-hive/hypershiftlite/thirdparty/etcd/v1beta2"
 )
 
@@ -14,6 +17,9 @@ var (
 
 func init() {
 	clientgoscheme.AddToScheme(Scheme)
+	apiextensionsv1.AddToScheme(Scheme)
+	hyperlitev1alpha1.AddToScheme(Scheme)
 	hyperlitev1.AddToScheme(Scheme)
 	etcdv1.AddToScheme(Scheme)
+	routev1.Install(Scheme)
 }
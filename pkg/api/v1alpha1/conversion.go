@@ -0,0 +1,315 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
+)
+
+// LifecycleAnnotation holds the v1beta1 lifecycle settings of a
+// KubernetesService read as v1alpha1, which has no equivalent fields, so that
+// they are preserved when the object is written back.
+const LifecycleAnnotation = "hypershiftlite.openshift.io/v1beta1-lifecycle"
+
+// SourceAnnotation holds the v1beta1 source of a KubernetesService read as
+// v1alpha1, like LifecycleAnnotation.
+const SourceAnnotation = "hypershiftlite.openshift.io/v1beta1-source"
+
+// ConvertTo converts this KubernetesService to the v1beta1 hub version.
+func (src *KubernetesService) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.KubernetesService)
+	in := src.DeepCopy()
+
+	dst.ObjectMeta = in.ObjectMeta
+	if raw, ok := dst.Annotations[LifecycleAnnotation]; ok {
+		if err := json.Unmarshal([]byte(raw), &dst.Spec.Lifecycle); err != nil {
+			return fmt.Errorf("invalid %s annotation: %w", LifecycleAnnotation, err)
+		}
+		delete(dst.Annotations, LifecycleAnnotation)
+	}
+	if raw, ok := dst.Annotations[SourceAnnotation]; ok {
+		if err := json.Unmarshal([]byte(raw), &dst.Spec.Source); err != nil {
+			return fmt.Errorf("invalid %s annotation: %w", SourceAnnotation, err)
+		}
+		delete(dst.Annotations, SourceAnnotation)
+	}
+	if len(dst.Annotations) == 0 {
+		dst.Annotations = nil
+	}
+
+	dst.Spec.ControlPlaneNamespace = v1beta1.ControlPlaneNamespaceMode(in.Spec.ControlPlaneNamespace)
+	dst.Spec.Release = v1beta1.ReleaseSpec{
+		Image:      in.Spec.ReleaseImage,
+		PullSecret: in.Spec.PullSecret,
+	}
+	dst.Spec.Networking = v1beta1.NetworkingSpec{
+		ClusterNetworkSpec: v1beta1.ClusterNetworkSpec(in.Spec.Networking),
+		APIServerPublishing: v1beta1.APIServerPublishingSpec{
+			Type:             v1beta1.PublishingStrategyType(in.Spec.APIServerPublishing.Type),
+			Hostname:         in.Spec.APIServerPublishing.Hostname,
+			NodePort:         in.Spec.APIServerPublishing.NodePort,
+			IngressClassName: in.Spec.APIServerPublishing.IngressClassName,
+		},
+	}
+	dst.Spec.Components = v1beta1.ComponentsSpec{
+		AvailabilityPolicy: v1beta1.AvailabilityPolicy(in.Spec.ControllerAvailabilityPolicy),
+		FeatureGates:       in.Spec.FeatureGates,
+		KubeAPIServer: v1beta1.KubeAPIServerSpec{
+			ComponentSpec: componentToV1beta1(in.Spec.Components.KubeAPIServer),
+			ExtraArgs:     in.Spec.APIServer.ExtraArgs,
+			Admission: v1beta1.AdmissionSpec{
+				Profile:         v1beta1.AdmissionProfileType(in.Spec.APIServer.Admission.Profile),
+				EnabledPlugins:  in.Spec.APIServer.Admission.EnabledPlugins,
+				DisabledPlugins: in.Spec.APIServer.Admission.DisabledPlugins,
+			},
+		},
+		KubeControllerManager: v1beta1.KubeControllerManagerSpec{
+			ComponentSpec: componentToV1beta1(in.Spec.Components.KubeControllerManager),
+			ExtraArgs:     in.Spec.ControllerManager.ExtraArgs,
+		},
+		Etcd:         componentToV1beta1(in.Spec.Components.Etcd),
+		EtcdOperator: componentToV1beta1(in.Spec.Components.EtcdOperator),
+	}
+	for _, cert := range in.Spec.APIServer.ServingCerts {
+		dst.Spec.Components.KubeAPIServer.ServingCerts = append(dst.Spec.Components.KubeAPIServer.ServingCerts, v1beta1.APIServerNamedServingCert(cert))
+	}
+	for _, config := range in.Spec.APIServer.Admission.PluginConfig {
+		dst.Spec.Components.KubeAPIServer.Admission.PluginConfig = append(dst.Spec.Components.KubeAPIServer.Admission.PluginConfig, v1beta1.AdmissionPluginConfig(config))
+	}
+	dst.Spec.Security = v1beta1.SecuritySpec{
+		Audit: v1beta1.AuditSpec{
+			Profile:      v1beta1.AuditProfileType(in.Spec.Audit.Profile),
+			CustomPolicy: in.Spec.Audit.CustomPolicy,
+		},
+		Authentication: v1beta1.AuthenticationSpec{
+			OIDC:    (*v1beta1.OIDCSpec)(in.Spec.Authentication.OIDC),
+			Webhook: (*v1beta1.WebhookTokenAuthenticatorSpec)(in.Spec.Authentication.Webhook),
+		},
+		Authorization: v1beta1.AuthorizationSpec{
+			Webhook: (*v1beta1.WebhookAuthorizerSpec)(in.Spec.Authorization.Webhook),
+		},
+	}
+	if encryption := in.Spec.SecretEncryption; encryption != nil {
+		dst.Spec.Security.SecretEncryption = &v1beta1.SecretEncryptionSpec{
+			Type:             v1beta1.SecretEncryptionType(encryption.Type),
+			RotationInterval: encryption.RotationInterval,
+			KMS:              (*v1beta1.KMSSpec)(encryption.KMS),
+		}
+	}
+
+	dst.Status = v1beta1.KubernetesServiceStatus{
+		ObservedGeneration:    in.Status.ObservedGeneration,
+		ControlPlaneNamespace: in.Status.ControlPlaneNamespace,
+		Version:               in.Status.Version,
+		ComponentVersions:     in.Status.ComponentVersions,
+		ReleaseImageDigest:    in.Status.ReleaseImageDigest,
+		InternalAPIEndpoint:   (*v1beta1.APIEndpoint)(in.Status.InternalAPIEndpoint),
+		Networking:            (*v1beta1.ClusterNetworkSpec)(in.Status.Networking),
+		ExternalAPIEndpoint:   (*v1beta1.APIEndpoint)(in.Status.ExternalAPIEndpoint),
+		Kubeconfigs:           v1beta1.KubeconfigSecrets(in.Status.Kubeconfigs),
+		Hibernation:           (*v1beta1.HibernationStatus)(in.Status.Hibernation),
+		Expiration:            (*v1beta1.ExpirationStatus)(in.Status.Expiration),
+		Source:                (*v1beta1.SourceStatus)(in.Status.Source),
+	}
+	for _, condition := range in.Status.Conditions {
+		dst.Status.Conditions = append(dst.Status.Conditions, v1beta1.KubernetesServiceCondition{
+			Type:               v1beta1.ConditionType(condition.Type),
+			Status:             condition.Status,
+			LastTransitionTime: condition.LastTransitionTime,
+			Reason:             condition.Reason,
+			Message:            condition.Message,
+		})
+	}
+	if encryption := in.Status.SecretEncryption; encryption != nil {
+		dst.Status.SecretEncryption = &v1beta1.SecretEncryptionStatus{
+			RotationPhase:    v1beta1.EncryptionKeyRotationPhase(encryption.RotationPhase),
+			LastRotationTime: encryption.LastRotationTime,
+		}
+		for _, key := range encryption.Keys {
+			dst.Status.SecretEncryption.Keys = append(dst.Status.SecretEncryption.Keys, v1beta1.EncryptionKey{
+				Name: key.Name,
+				Type: v1beta1.SecretEncryptionType(key.Type),
+			})
+		}
+	}
+	for _, entry := range in.Status.History {
+		dst.Status.History = append(dst.Status.History, v1beta1.ReleaseHistory{
+			State:          v1beta1.UpgradeState(entry.State),
+			Version:        entry.Version,
+			Image:          entry.Image,
+			StartedTime:    entry.StartedTime,
+			CompletionTime: entry.CompletionTime,
+			Snapshot:       entry.Snapshot,
+		})
+	}
+	if upgrade := in.Status.Upgrade; upgrade != nil {
+		dst.Status.Upgrade = &v1beta1.UpgradeStatus{
+			Phase:          v1beta1.UpgradePhase(upgrade.Phase),
+			Image:          upgrade.Image,
+			PreviousImage:  upgrade.PreviousImage,
+			PhaseStartTime: upgrade.PhaseStartTime,
+		}
+	}
+	return nil
+}
+
+// ConvertFrom converts a KubernetesService from the v1beta1 hub version to
+// this version.
+func (dst *KubernetesService) ConvertFrom(srcRaw conversion.Hub) error {
+	in := srcRaw.(*v1beta1.KubernetesService).DeepCopy()
+
+	dst.ObjectMeta = in.ObjectMeta
+	if !equality.Semantic.DeepEqual(in.Spec.Lifecycle, v1beta1.LifecycleSpec{}) {
+		raw, err := json.Marshal(in.Spec.Lifecycle)
+		if err != nil {
+			return fmt.Errorf("failed to serialize lifecycle: %w", err)
+		}
+		if dst.Annotations == nil {
+			dst.Annotations = map[string]string{}
+		}
+		dst.Annotations[LifecycleAnnotation] = string(raw)
+	}
+	if in.Spec.Source != nil {
+		raw, err := json.Marshal(in.Spec.Source)
+		if err != nil {
+			return fmt.Errorf("failed to serialize source: %w", err)
+		}
+		if dst.Annotations == nil {
+			dst.Annotations = map[string]string{}
+		}
+		dst.Annotations[SourceAnnotation] = string(raw)
+	}
+
+	kubeAPIServer := in.Spec.Components.KubeAPIServer
+	dst.Spec = KubernetesServiceSpec{
+		ReleaseImage:                 in.Spec.Release.Image,
+		PullSecret:                   in.Spec.Release.PullSecret,
+		Networking:                   NetworkingSpec(in.Spec.Networking.ClusterNetworkSpec),
+		ControllerAvailabilityPolicy: AvailabilityPolicy(in.Spec.Components.AvailabilityPolicy),
+		Components: ComponentsSpec{
+			KubeAPIServer:         componentFromV1beta1(kubeAPIServer.ComponentSpec),
+			KubeControllerManager: componentFromV1beta1(in.Spec.Components.KubeControllerManager.ComponentSpec),
+			Etcd:                  componentFromV1beta1(in.Spec.Components.Etcd),
+			EtcdOperator:          componentFromV1beta1(in.Spec.Components.EtcdOperator),
+		},
+		APIServerPublishing: APIServerPublishingSpec{
+			Type:             PublishingStrategyType(in.Spec.Networking.APIServerPublishing.Type),
+			Hostname:         in.Spec.Networking.APIServerPublishing.Hostname,
+			NodePort:         in.Spec.Networking.APIServerPublishing.NodePort,
+			IngressClassName: in.Spec.Networking.APIServerPublishing.IngressClassName,
+		},
+		APIServer: APIServerSpec{
+			ExtraArgs: kubeAPIServer.ExtraArgs,
+			Admission: AdmissionSpec{
+				Profile:         AdmissionProfileType(kubeAPIServer.Admission.Profile),
+				EnabledPlugins:  kubeAPIServer.Admission.EnabledPlugins,
+				DisabledPlugins: kubeAPIServer.Admission.DisabledPlugins,
+			},
+		},
+		ControllerManager: ControllerManagerSpec{
+			ExtraArgs: in.Spec.Components.KubeControllerManager.ExtraArgs,
+		},
+		FeatureGates: in.Spec.Components.FeatureGates,
+		Audit: AuditSpec{
+			Profile:      AuditProfileType(in.Spec.Security.Audit.Profile),
+			CustomPolicy: in.Spec.Security.Audit.CustomPolicy,
+		},
+		Authentication: AuthenticationSpec{
+			OIDC:    (*OIDCSpec)(in.Spec.Security.Authentication.OIDC),
+			Webhook: (*WebhookTokenAuthenticatorSpec)(in.Spec.Security.Authentication.Webhook),
+		},
+		Authorization: AuthorizationSpec{
+			Webhook: (*WebhookAuthorizerSpec)(in.Spec.Security.Authorization.Webhook),
+		},
+		ControlPlaneNamespace: ControlPlaneNamespaceMode(in.Spec.ControlPlaneNamespace),
+	}
+	for _, cert := range kubeAPIServer.ServingCerts {
+		dst.Spec.APIServer.ServingCerts = append(dst.Spec.APIServer.ServingCerts, APIServerNamedServingCert(cert))
+	}
+	for _, config := range kubeAPIServer.Admission.PluginConfig {
+		dst.Spec.APIServer.Admission.PluginConfig = append(dst.Spec.APIServer.Admission.PluginConfig, AdmissionPluginConfig(config))
+	}
+	if encryption := in.Spec.Security.SecretEncryption; encryption != nil {
+		dst.Spec.SecretEncryption = &SecretEncryptionSpec{
+			Type:             SecretEncryptionType(encryption.Type),
+			RotationInterval: encryption.RotationInterval,
+			KMS:              (*KMSSpec)(encryption.KMS),
+		}
+	}
+
+	dst.Status = KubernetesServiceStatus{
+		ObservedGeneration:    in.Status.ObservedGeneration,
+		ControlPlaneNamespace: in.Status.ControlPlaneNamespace,
+		Version:               in.Status.Version,
+		ComponentVersions:     in.Status.ComponentVersions,
+		ReleaseImageDigest:    in.Status.ReleaseImageDigest,
+		InternalAPIEndpoint:   (*APIEndpoint)(in.Status.InternalAPIEndpoint),
+		Networking:            (*NetworkingSpec)(in.Status.Networking),
+		ExternalAPIEndpoint:   (*APIEndpoint)(in.Status.ExternalAPIEndpoint),
+		Kubeconfigs:           KubeconfigSecrets(in.Status.Kubeconfigs),
+		Hibernation:           (*HibernationStatus)(in.Status.Hibernation),
+		Expiration:            (*ExpirationStatus)(in.Status.Expiration),
+		Source:                (*SourceStatus)(in.Status.Source),
+	}
+	for _, condition := range in.Status.Conditions {
+		dst.Status.Conditions = append(dst.Status.Conditions, KubernetesServiceCondition{
+			Type:               ConditionType(condition.Type),
+			Status:             condition.Status,
+			LastTransitionTime: condition.LastTransitionTime,
+			Reason:             condition.Reason,
+			Message:            condition.Message,
+		})
+	}
+	if encryption := in.Status.SecretEncryption; encryption != nil {
+		dst.Status.SecretEncryption = &SecretEncryptionStatus{
+			RotationPhase:    EncryptionKeyRotationPhase(encryption.RotationPhase),
+			LastRotationTime: encryption.LastRotationTime,
+		}
+		for _, key := range encryption.Keys {
+			dst.Status.SecretEncryption.Keys = append(dst.Status.SecretEncryption.Keys, EncryptionKey{
+				Name: key.Name,
+				Type: SecretEncryptionType(key.Type),
+			})
+		}
+	}
+	for _, entry := range in.Status.History {
+		dst.Status.History = append(dst.Status.History, ReleaseHistory{
+			State:          UpgradeState(entry.State),
+			Version:        entry.Version,
+			Image:          entry.Image,
+			StartedTime:    entry.StartedTime,
+			CompletionTime: entry.CompletionTime,
+			Snapshot:       entry.Snapshot,
+		})
+	}
+	if upgrade := in.Status.Upgrade; upgrade != nil {
+		dst.Status.Upgrade = &UpgradeStatus{
+			Phase:          UpgradePhase(upgrade.Phase),
+			Image:          upgrade.Image,
+			PreviousImage:  upgrade.PreviousImage,
+			PhaseStartTime: upgrade.PhaseStartTime,
+		}
+	}
+	return nil
+}
+
+func componentToV1beta1(component *ComponentSpec) v1beta1.ComponentSpec {
+	if component == nil {
+		return v1beta1.ComponentSpec{}
+	}
+	return v1beta1.ComponentSpec(*component)
+}
+
+// componentFromV1beta1 returns nil for components without settings, which
+// v1alpha1 does not distinguish from empty settings.
+func componentFromV1beta1(component v1beta1.ComponentSpec) *ComponentSpec {
+	if equality.Semantic.DeepEqual(component, v1beta1.ComponentSpec{}) {
+		return nil
+	}
+	result := ComponentSpec(component)
+	return &result
+}
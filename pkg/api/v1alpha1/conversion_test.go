@@ -0,0 +1,464 @@
+package v1alpha1
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/diff"
+	"k8s.io/utils/pointer"
+
+	"github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
+)
+
+var (
+	// now is truncated to seconds, the precision of times serialized in
+	// annotations.
+	now   = metav1.NewTime(time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC))
+	later = metav1.NewTime(now.Add(time.Hour))
+)
+
+func component(priorityClassName string) *ComponentSpec {
+	return &ComponentSpec{
+		Resources: &corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("500m"),
+				corev1.ResourceMemory: resource.MustParse("2Gi"),
+			},
+		},
+		NodeSelector: map[string]string{"node-role.kubernetes.io/infra": ""},
+		Tolerations: []corev1.Toleration{
+			{
+				Key:      "node-role.kubernetes.io/infra",
+				Operator: corev1.TolerationOpExists,
+				Effect:   corev1.TaintEffectNoSchedule,
+			},
+		},
+		TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+			{
+				MaxSkew:           1,
+				TopologyKey:       "topology.kubernetes.io/zone",
+				WhenUnsatisfiable: corev1.ScheduleAnyway,
+			},
+		},
+		PriorityClassName: priorityClassName,
+	}
+}
+
+func fullKubernetesService(t *testing.T) *KubernetesService {
+	lifecycle, err := json.Marshal(v1beta1.LifecycleSpec{
+		DeletionPolicy: v1beta1.SnapshotDeletionPolicy,
+		PowerState:     v1beta1.HibernatingPowerState,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &KubernetesService{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test",
+			Name:      "mykube",
+			Annotations: map[string]string{
+				"example.com/other": "value",
+				LifecycleAnnotation: string(lifecycle),
+			},
+		},
+		Spec: KubernetesServiceSpec{
+			ReleaseImage: "quay.io/openshift-release-dev/ocp-release:4.7.0-x86_64",
+			PullSecret:   corev1.LocalObjectReference{Name: "pull-secret"},
+			Networking: NetworkingSpec{
+				ServiceCIDR:      "172.30.0.0/16",
+				PodCIDR:          "10.128.0.0/14",
+				ClusterDomain:    "cluster.local",
+				AdvertiseAddress: "172.20.0.1",
+			},
+			ControllerAvailabilityPolicy: HighlyAvailable,
+			Components: ComponentsSpec{
+				KubeAPIServer:         component("kube-apiserver"),
+				KubeControllerManager: component("kube-controller-manager"),
+				Etcd:                  component("etcd"),
+				EtcdOperator:          component("etcd-operator"),
+			},
+			APIServerPublishing: APIServerPublishingSpec{
+				Type:             IngressPublishing,
+				Hostname:         "api.mykube.example.com",
+				NodePort:         30443,
+				IngressClassName: pointer.StringPtr("passthrough"),
+			},
+			APIServer: APIServerSpec{
+				ServingCerts: []APIServerNamedServingCert{
+					{
+						Names:              []string{"api.mykube.example.com"},
+						ServingCertificate: corev1.LocalObjectReference{Name: "api-cert"},
+					},
+				},
+				ExtraArgs: map[string]string{"max-requests-inflight": "800"},
+				Admission: AdmissionSpec{
+					Profile:         UpstreamAdmissionProfile,
+					EnabledPlugins:  []string{"AlwaysPullImages"},
+					DisabledPlugins: []string{"DefaultStorageClass"},
+					PluginConfig: []AdmissionPluginConfig{
+						{
+							Name:          "EventRateLimit",
+							Configuration: runtime.RawExtension{Raw: []byte(`{"kind":"Configuration"}`)},
+						},
+					},
+				},
+			},
+			ControllerManager: ControllerManagerSpec{
+				ExtraArgs: map[string]string{"concurrent-deployment-syncs": "10"},
+			},
+			FeatureGates: map[string]bool{"EphemeralContainers": true},
+			Audit: AuditSpec{
+				Profile:      WriteRequestBodiesAuditProfile,
+				CustomPolicy: &corev1.LocalObjectReference{Name: "audit-policy"},
+			},
+			Authentication: AuthenticationSpec{
+				OIDC: &OIDCSpec{
+					IssuerURL:      "https://sso.example.com",
+					ClientID:       "kube",
+					UsernameClaim:  "email",
+					UsernamePrefix: "oidc:",
+					GroupsClaim:    "groups",
+					GroupsPrefix:   "oidc:",
+					CA:             &corev1.LocalObjectReference{Name: "oidc-ca"},
+				},
+				Webhook: &WebhookTokenAuthenticatorSpec{
+					KubeConfig: corev1.LocalObjectReference{Name: "authn-webhook"},
+					CacheTTL:   &metav1.Duration{Duration: time.Minute},
+				},
+			},
+			Authorization: AuthorizationSpec{
+				Webhook: &WebhookAuthorizerSpec{
+					KubeConfig:      corev1.LocalObjectReference{Name: "authz-webhook"},
+					AuthorizedTTL:   &metav1.Duration{Duration: time.Minute},
+					UnauthorizedTTL: &metav1.Duration{Duration: 30 * time.Second},
+				},
+			},
+			SecretEncryption: &SecretEncryptionSpec{
+				Type:             KMSEncryption,
+				RotationInterval: &metav1.Duration{Duration: 720 * time.Hour},
+				KMS: &KMSSpec{
+					Image:       "quay.io/example/kms-plugin:latest",
+					Command:     []string{"/usr/bin/kms-plugin"},
+					Args:        []string{"--key-service=https://keys.example.com"},
+					Env:         []corev1.EnvVar{{Name: "DEBUG", Value: "true"}},
+					Credentials: &corev1.LocalObjectReference{Name: "kms-credentials"},
+					Resources: &corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")},
+					},
+					CacheSize: pointer.Int32Ptr(500),
+					Timeout:   &metav1.Duration{Duration: 5 * time.Second},
+				},
+			},
+			ControlPlaneNamespace: DedicatedNamespace,
+		},
+		Status: KubernetesServiceStatus{
+			Conditions: []KubernetesServiceCondition{
+				{
+					Type:               Available,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: now,
+					Reason:             "AsExpected",
+					Message:            "Kubernetes service is available",
+				},
+				{
+					// Conditions added in later versions are kept
+					Type:               ConditionType(v1beta1.Upgrading),
+					Status:             corev1.ConditionFalse,
+					LastTransitionTime: now,
+					Reason:             "Completed",
+				},
+			},
+			ObservedGeneration:    3,
+			ControlPlaneNamespace: "test-mykube",
+			Version:               "4.7.0",
+			ComponentVersions:     map[string]string{"kubernetes": "1.20.0"},
+			ReleaseImageDigest:    "sha256:0123456789abcdef",
+			InternalAPIEndpoint:   &APIEndpoint{Host: "kube-apiserver.test-mykube.svc", Port: 6443},
+			Networking: &NetworkingSpec{
+				ServiceCIDR:   "172.30.0.0/16",
+				PodCIDR:       "10.128.0.0/14",
+				ClusterDomain: "cluster.local",
+			},
+			ExternalAPIEndpoint: &APIEndpoint{Host: "api.mykube.example.com", Port: 443},
+			Kubeconfigs: KubeconfigSecrets{
+				Service:   &corev1.LocalObjectReference{Name: "mykube-kubeconfig"},
+				Localhost: &corev1.LocalObjectReference{Name: "mykube-localhost-kubeconfig"},
+				External:  &corev1.LocalObjectReference{Name: "mykube-external-kubeconfig"},
+			},
+			SecretEncryption: &SecretEncryptionStatus{
+				Keys: []EncryptionKey{
+					{Name: "key-20210601T120000Z", Type: KMSEncryption},
+					{Name: "key-20210501T120000Z", Type: AESCBCEncryption},
+				},
+				RotationPhase:    EncryptionKeyPromoted,
+				LastRotationTime: &now,
+			},
+			Hibernation: &HibernationStatus{
+				Snapshot:     "s3://snapshots/test/mykube/hibernation-20210601T120000Z",
+				SnapshotTime: &now,
+			},
+			Expiration: &ExpirationStatus{
+				ExpiresAt:   later,
+				Remaining:   "1h0m0s",
+				LastWarning: &metav1.Duration{Duration: time.Hour},
+			},
+			Source: &SourceStatus{
+				Snapshot:       "s3://snapshots/test/mykube/clone-20210601T120000Z",
+				SnapshotTime:   &now,
+				RestoreTime:    &now,
+				CompletionTime: &later,
+			},
+			History: []ReleaseHistory{
+				{
+					State:       PartialUpgradeState,
+					Version:     "4.7.1",
+					Image:       "quay.io/openshift-release-dev/ocp-release:4.7.1-x86_64",
+					StartedTime: later,
+					Snapshot:    "s3://snapshots/test/mykube/upgrade-20210601T130000Z",
+				},
+				{
+					State:          CompletedUpgradeState,
+					Version:        "4.7.0",
+					Image:          "quay.io/openshift-release-dev/ocp-release:4.7.0-x86_64",
+					StartedTime:    now,
+					CompletionTime: &now,
+				},
+			},
+			Upgrade: &UpgradeStatus{
+				Phase:          KubeAPIServerUpgradePhase,
+				Image:          "quay.io/openshift-release-dev/ocp-release:4.7.1-x86_64",
+				PreviousImage:  "quay.io/openshift-release-dev/ocp-release:4.7.0-x86_64",
+				PhaseStartTime: later,
+			},
+		},
+	}
+}
+
+func TestRoundTripFromV1alpha1(t *testing.T) {
+	tests := []struct {
+		name string
+		in   *KubernetesService
+	}{
+		{
+			name: "every field set",
+			in:   fullKubernetesService(t),
+		},
+		{
+			name: "no field set",
+			in:   &KubernetesService{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hub := &v1beta1.KubernetesService{}
+			if err := test.in.DeepCopy().ConvertTo(hub); err != nil {
+				t.Fatalf("ConvertTo failed: %v", err)
+			}
+			out := &KubernetesService{}
+			if err := out.ConvertFrom(hub); err != nil {
+				t.Fatalf("ConvertFrom failed: %v", err)
+			}
+			if !equality.Semantic.DeepEqual(test.in, out) {
+				t.Errorf("round trip changed the object: %s", diff.ObjectReflectDiff(test.in, out))
+			}
+		})
+	}
+}
+
+func TestConvertToReadsLifecycleAnnotation(t *testing.T) {
+	hub := &v1beta1.KubernetesService{}
+	if err := fullKubernetesService(t).ConvertTo(hub); err != nil {
+		t.Fatalf("ConvertTo failed: %v", err)
+	}
+	expected := v1beta1.LifecycleSpec{
+		DeletionPolicy: v1beta1.SnapshotDeletionPolicy,
+		PowerState:     v1beta1.HibernatingPowerState,
+	}
+	if !equality.Semantic.DeepEqual(hub.Spec.Lifecycle, expected) {
+		t.Errorf("unexpected lifecycle: %s", diff.ObjectReflectDiff(expected, hub.Spec.Lifecycle))
+	}
+	if _, ok := hub.Annotations[LifecycleAnnotation]; ok {
+		t.Errorf("%s annotation was not removed", LifecycleAnnotation)
+	}
+	if hub.Annotations["example.com/other"] != "value" {
+		t.Errorf("other annotations were not kept: %v", hub.Annotations)
+	}
+}
+
+func TestRoundTripFromV1beta1(t *testing.T) {
+	tests := []struct {
+		name            string
+		spec            v1beta1.KubernetesServiceSpec
+		annotations     map[string]string
+		wantAnnotations []string
+	}{
+		{
+			name: "lifecycle",
+			spec: v1beta1.KubernetesServiceSpec{
+				Lifecycle: v1beta1.LifecycleSpec{
+					DeletionPolicy: v1beta1.SnapshotDeletionPolicy,
+					SnapshotStorage: &v1beta1.SnapshotStorageSpec{
+						S3: v1beta1.S3SnapshotStorage{
+							Bucket:         "snapshots",
+							Prefix:         "hypershiftlite",
+							Endpoint:       "https://s3.example.com",
+							ForcePathStyle: true,
+							Credentials:    corev1.LocalObjectReference{Name: "snapshot-credentials"},
+						},
+					},
+					PowerState:     v1beta1.HibernatingPowerState,
+					PausedUntil:    "true",
+					Lifetime:       &metav1.Duration{Duration: 24 * time.Hour},
+					ExpiresAt:      &later,
+					ExpiryWarnings: []metav1.Duration{{Duration: time.Hour}, {Duration: 10 * time.Minute}},
+				},
+			},
+			wantAnnotations: []string{LifecycleAnnotation},
+		},
+		{
+			name: "source from a kubernetes service",
+			spec: v1beta1.KubernetesServiceSpec{
+				Source: &v1beta1.SourceSpec{
+					FromKubernetesService: &corev1.LocalObjectReference{Name: "source"},
+				},
+			},
+			wantAnnotations: []string{SourceAnnotation},
+		},
+		{
+			name: "lifecycle and source from a snapshot",
+			spec: v1beta1.KubernetesServiceSpec{
+				Lifecycle: v1beta1.LifecycleSpec{
+					DeletionPolicy: v1beta1.DeleteDeletionPolicy,
+				},
+				Source: &v1beta1.SourceSpec{
+					FromSnapshot: &v1beta1.SnapshotSource{Path: "s3://snapshots/test/old/final-20210601T120000Z"},
+				},
+			},
+			annotations:     map[string]string{"example.com/other": "value"},
+			wantAnnotations: []string{"example.com/other", LifecycleAnnotation, SourceAnnotation},
+		},
+		{
+			name: "neither lifecycle nor source",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := &v1beta1.KubernetesService{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace:   "test",
+					Name:        "mykube",
+					Annotations: test.annotations,
+				},
+				Spec: test.spec,
+			}
+			spoke := &KubernetesService{}
+			if err := spoke.ConvertFrom(in.DeepCopy()); err != nil {
+				t.Fatalf("ConvertFrom failed: %v", err)
+			}
+			if len(spoke.Annotations) != len(test.wantAnnotations) {
+				t.Errorf("expected annotations %v, got %v", test.wantAnnotations, spoke.Annotations)
+			}
+			for _, annotation := range test.wantAnnotations {
+				if _, ok := spoke.Annotations[annotation]; !ok {
+					t.Errorf("expected annotation %s, got %v", annotation, spoke.Annotations)
+				}
+			}
+
+			out := &v1beta1.KubernetesService{}
+			if err := spoke.ConvertTo(out); err != nil {
+				t.Fatalf("ConvertTo failed: %v", err)
+			}
+			if !equality.Semantic.DeepEqual(in, out) {
+				t.Errorf("round trip changed the object: %s", diff.ObjectReflectDiff(in, out))
+			}
+			for _, annotation := range []string{LifecycleAnnotation, SourceAnnotation} {
+				if _, ok := out.Annotations[annotation]; ok {
+					t.Errorf("%s annotation was not removed", annotation)
+				}
+			}
+		})
+	}
+}
+
+func TestConvertToRejectsInvalidAnnotations(t *testing.T) {
+	for _, annotation := range []string{LifecycleAnnotation, SourceAnnotation} {
+		t.Run(annotation, func(t *testing.T) {
+			in := &KubernetesService{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{annotation: "{"},
+				},
+			}
+			if err := in.ConvertTo(&v1beta1.KubernetesService{}); err == nil {
+				t.Errorf("expected an error for an invalid %s annotation", annotation)
+			}
+		})
+	}
+}
+
+func TestComponentSpecConversion(t *testing.T) {
+	tests := []struct {
+		name string
+		in   *ComponentSpec
+		// want is the component after a round trip through v1beta1, which
+		// does not distinguish a component without settings from one with
+		// empty settings
+		want *ComponentSpec
+	}{
+		{
+			name: "nil",
+			in:   nil,
+			want: nil,
+		},
+		{
+			name: "empty",
+			in:   &ComponentSpec{},
+			want: nil,
+		},
+		{
+			name: "set",
+			in:   component("system-cluster-critical"),
+			want: component("system-cluster-critical"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := &KubernetesService{
+				Spec: KubernetesServiceSpec{
+					Components: ComponentsSpec{
+						KubeAPIServer:         test.in,
+						KubeControllerManager: test.in,
+						Etcd:                  test.in,
+						EtcdOperator:          test.in,
+					},
+				},
+			}
+			hub := &v1beta1.KubernetesService{}
+			if err := in.ConvertTo(hub); err != nil {
+				t.Fatalf("ConvertTo failed: %v", err)
+			}
+			if test.want == nil && !equality.Semantic.DeepEqual(hub.Spec.Components.Etcd, v1beta1.ComponentSpec{}) {
+				t.Errorf("expected empty v1beta1 component, got %#v", hub.Spec.Components.Etcd)
+			}
+			out := &KubernetesService{}
+			if err := out.ConvertFrom(hub); err != nil {
+				t.Fatalf("ConvertFrom failed: %v", err)
+			}
+			for name, got := range map[string]*ComponentSpec{
+				"kubeAPIServer":         out.Spec.Components.KubeAPIServer,
+				"kubeControllerManager": out.Spec.Components.KubeControllerManager,
+				"etcd":                  out.Spec.Components.Etcd,
+				"etcdOperator":          out.Spec.Components.EtcdOperator,
+			} {
+				if !equality.Semantic.DeepEqual(test.want, got) {
+					t.Errorf("unexpected %s component: %s", name, diff.ObjectReflectDiff(test.want, got))
+				}
+			}
+		})
+	}
+}
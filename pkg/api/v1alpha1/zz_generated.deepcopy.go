@@ -21,15 +21,451 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIEndpoint) DeepCopyInto(out *APIEndpoint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIEndpoint.
+func (in *APIEndpoint) DeepCopy() *APIEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(APIEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIServerNamedServingCert) DeepCopyInto(out *APIServerNamedServingCert) {
+	*out = *in
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	out.ServingCertificate = in.ServingCertificate
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerNamedServingCert.
+func (in *APIServerNamedServingCert) DeepCopy() *APIServerNamedServingCert {
+	if in == nil {
+		return nil
+	}
+	out := new(APIServerNamedServingCert)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIServerPublishingSpec) DeepCopyInto(out *APIServerPublishingSpec) {
+	*out = *in
+	if in.IngressClassName != nil {
+		in, out := &in.IngressClassName, &out.IngressClassName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerPublishingSpec.
+func (in *APIServerPublishingSpec) DeepCopy() *APIServerPublishingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(APIServerPublishingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIServerSpec) DeepCopyInto(out *APIServerSpec) {
+	*out = *in
+	if in.ServingCerts != nil {
+		in, out := &in.ServingCerts, &out.ServingCerts
+		*out = make([]APIServerNamedServingCert, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Admission.DeepCopyInto(&out.Admission)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerSpec.
+func (in *APIServerSpec) DeepCopy() *APIServerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(APIServerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdmissionPluginConfig) DeepCopyInto(out *AdmissionPluginConfig) {
+	*out = *in
+	in.Configuration.DeepCopyInto(&out.Configuration)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionPluginConfig.
+func (in *AdmissionPluginConfig) DeepCopy() *AdmissionPluginConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmissionPluginConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdmissionSpec) DeepCopyInto(out *AdmissionSpec) {
+	*out = *in
+	if in.EnabledPlugins != nil {
+		in, out := &in.EnabledPlugins, &out.EnabledPlugins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DisabledPlugins != nil {
+		in, out := &in.DisabledPlugins, &out.DisabledPlugins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PluginConfig != nil {
+		in, out := &in.PluginConfig, &out.PluginConfig
+		*out = make([]AdmissionPluginConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionSpec.
+func (in *AdmissionSpec) DeepCopy() *AdmissionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmissionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditSpec) DeepCopyInto(out *AuditSpec) {
+	*out = *in
+	if in.CustomPolicy != nil {
+		in, out := &in.CustomPolicy, &out.CustomPolicy
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditSpec.
+func (in *AuditSpec) DeepCopy() *AuditSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthenticationSpec) DeepCopyInto(out *AuthenticationSpec) {
+	*out = *in
+	if in.OIDC != nil {
+		in, out := &in.OIDC, &out.OIDC
+		*out = new(OIDCSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(WebhookTokenAuthenticatorSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthenticationSpec.
+func (in *AuthenticationSpec) DeepCopy() *AuthenticationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthenticationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuthorizationSpec) DeepCopyInto(out *AuthorizationSpec) {
+	*out = *in
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(WebhookAuthorizerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuthorizationSpec.
+func (in *AuthorizationSpec) DeepCopy() *AuthorizationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AuthorizationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentSpec) DeepCopyInto(out *ComponentSpec) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentSpec.
+func (in *ComponentSpec) DeepCopy() *ComponentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentsSpec) DeepCopyInto(out *ComponentsSpec) {
+	*out = *in
+	if in.KubeAPIServer != nil {
+		in, out := &in.KubeAPIServer, &out.KubeAPIServer
+		*out = new(ComponentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KubeControllerManager != nil {
+		in, out := &in.KubeControllerManager, &out.KubeControllerManager
+		*out = new(ComponentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Etcd != nil {
+		in, out := &in.Etcd, &out.Etcd
+		*out = new(ComponentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EtcdOperator != nil {
+		in, out := &in.EtcdOperator, &out.EtcdOperator
+		*out = new(ComponentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentsSpec.
+func (in *ComponentsSpec) DeepCopy() *ComponentsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerManagerSpec) DeepCopyInto(out *ControllerManagerSpec) {
+	*out = *in
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerManagerSpec.
+func (in *ControllerManagerSpec) DeepCopy() *ControllerManagerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerManagerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EncryptionKey) DeepCopyInto(out *EncryptionKey) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionKey.
+func (in *EncryptionKey) DeepCopy() *EncryptionKey {
+	if in == nil {
+		return nil
+	}
+	out := new(EncryptionKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExpirationStatus) DeepCopyInto(out *ExpirationStatus) {
+	*out = *in
+	in.ExpiresAt.DeepCopyInto(&out.ExpiresAt)
+	if in.LastWarning != nil {
+		in, out := &in.LastWarning, &out.LastWarning
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExpirationStatus.
+func (in *ExpirationStatus) DeepCopy() *ExpirationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ExpirationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HibernationStatus) DeepCopyInto(out *HibernationStatus) {
+	*out = *in
+	if in.SnapshotTime != nil {
+		in, out := &in.SnapshotTime, &out.SnapshotTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HibernationStatus.
+func (in *HibernationStatus) DeepCopy() *HibernationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HibernationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KMSSpec) DeepCopyInto(out *KMSSpec) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Credentials != nil {
+		in, out := &in.Credentials, &out.Credentials
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CacheSize != nil {
+		in, out := &in.CacheSize, &out.CacheSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KMSSpec.
+func (in *KMSSpec) DeepCopy() *KMSSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KMSSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeconfigSecrets) DeepCopyInto(out *KubeconfigSecrets) {
+	*out = *in
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Localhost != nil {
+		in, out := &in.Localhost, &out.Localhost
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigSecrets.
+func (in *KubeconfigSecrets) DeepCopy() *KubeconfigSecrets {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeconfigSecrets)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubernetesService) DeepCopyInto(out *KubernetesService) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -103,6 +539,26 @@ func (in *KubernetesServiceList) DeepCopyObject() runtime.Object {
 func (in *KubernetesServiceSpec) DeepCopyInto(out *KubernetesServiceSpec) {
 	*out = *in
 	out.PullSecret = in.PullSecret
+	out.Networking = in.Networking
+	in.Components.DeepCopyInto(&out.Components)
+	in.APIServerPublishing.DeepCopyInto(&out.APIServerPublishing)
+	in.APIServer.DeepCopyInto(&out.APIServer)
+	in.ControllerManager.DeepCopyInto(&out.ControllerManager)
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Audit.DeepCopyInto(&out.Audit)
+	in.Authentication.DeepCopyInto(&out.Authentication)
+	in.Authorization.DeepCopyInto(&out.Authorization)
+	if in.SecretEncryption != nil {
+		in, out := &in.SecretEncryption, &out.SecretEncryption
+		*out = new(SecretEncryptionSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesServiceSpec.
@@ -125,6 +581,61 @@ func (in *KubernetesServiceStatus) DeepCopyInto(out *KubernetesServiceStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ComponentVersions != nil {
+		in, out := &in.ComponentVersions, &out.ComponentVersions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.InternalAPIEndpoint != nil {
+		in, out := &in.InternalAPIEndpoint, &out.InternalAPIEndpoint
+		*out = new(APIEndpoint)
+		**out = **in
+	}
+	if in.Networking != nil {
+		in, out := &in.Networking, &out.Networking
+		*out = new(NetworkingSpec)
+		**out = **in
+	}
+	if in.ExternalAPIEndpoint != nil {
+		in, out := &in.ExternalAPIEndpoint, &out.ExternalAPIEndpoint
+		*out = new(APIEndpoint)
+		**out = **in
+	}
+	in.Kubeconfigs.DeepCopyInto(&out.Kubeconfigs)
+	if in.SecretEncryption != nil {
+		in, out := &in.SecretEncryption, &out.SecretEncryption
+		*out = new(SecretEncryptionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hibernation != nil {
+		in, out := &in.Hibernation, &out.Hibernation
+		*out = new(HibernationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Expiration != nil {
+		in, out := &in.Expiration, &out.Expiration
+		*out = new(ExpirationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(SourceStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]ReleaseHistory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(UpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesServiceStatus.
@@ -136,3 +647,197 @@ func (in *KubernetesServiceStatus) DeepCopy() *KubernetesServiceStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkingSpec) DeepCopyInto(out *NetworkingSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkingSpec.
+func (in *NetworkingSpec) DeepCopy() *NetworkingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCSpec) DeepCopyInto(out *OIDCSpec) {
+	*out = *in
+	if in.CA != nil {
+		in, out := &in.CA, &out.CA
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCSpec.
+func (in *OIDCSpec) DeepCopy() *OIDCSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReleaseHistory) DeepCopyInto(out *ReleaseHistory) {
+	*out = *in
+	in.StartedTime.DeepCopyInto(&out.StartedTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReleaseHistory.
+func (in *ReleaseHistory) DeepCopy() *ReleaseHistory {
+	if in == nil {
+		return nil
+	}
+	out := new(ReleaseHistory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretEncryptionSpec) DeepCopyInto(out *SecretEncryptionSpec) {
+	*out = *in
+	if in.RotationInterval != nil {
+		in, out := &in.RotationInterval, &out.RotationInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.KMS != nil {
+		in, out := &in.KMS, &out.KMS
+		*out = new(KMSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretEncryptionSpec.
+func (in *SecretEncryptionSpec) DeepCopy() *SecretEncryptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretEncryptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretEncryptionStatus) DeepCopyInto(out *SecretEncryptionStatus) {
+	*out = *in
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]EncryptionKey, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastRotationTime != nil {
+		in, out := &in.LastRotationTime, &out.LastRotationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretEncryptionStatus.
+func (in *SecretEncryptionStatus) DeepCopy() *SecretEncryptionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SecretEncryptionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceStatus) DeepCopyInto(out *SourceStatus) {
+	*out = *in
+	if in.SnapshotTime != nil {
+		in, out := &in.SnapshotTime, &out.SnapshotTime
+		*out = (*in).DeepCopy()
+	}
+	if in.RestoreTime != nil {
+		in, out := &in.RestoreTime, &out.RestoreTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceStatus.
+func (in *SourceStatus) DeepCopy() *SourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeStatus) DeepCopyInto(out *UpgradeStatus) {
+	*out = *in
+	in.PhaseStartTime.DeepCopyInto(&out.PhaseStartTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeStatus.
+func (in *UpgradeStatus) DeepCopy() *UpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookAuthorizerSpec) DeepCopyInto(out *WebhookAuthorizerSpec) {
+	*out = *in
+	out.KubeConfig = in.KubeConfig
+	if in.AuthorizedTTL != nil {
+		in, out := &in.AuthorizedTTL, &out.AuthorizedTTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.UnauthorizedTTL != nil {
+		in, out := &in.UnauthorizedTTL, &out.UnauthorizedTTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookAuthorizerSpec.
+func (in *WebhookAuthorizerSpec) DeepCopy() *WebhookAuthorizerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookAuthorizerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookTokenAuthenticatorSpec) DeepCopyInto(out *WebhookTokenAuthenticatorSpec) {
+	*out = *in
+	out.KubeConfig = in.KubeConfig
+	if in.CacheTTL != nil {
+		in, out := &in.CacheTTL, &out.CacheTTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookTokenAuthenticatorSpec.
+func (in *WebhookTokenAuthenticatorSpec) DeepCopy() *WebhookTokenAuthenticatorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookTokenAuthenticatorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,5 @@
+package v1beta1
+
+// Hub marks v1beta1 as the version other versions of KubernetesService are
+// converted through.
+func (*KubernetesService) Hub() {}
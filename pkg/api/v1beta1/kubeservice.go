@@ -0,0 +1,1058 @@
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+func init() {
+	SchemeBuilder.Register(addKubernetesServiceToScheme)
+}
+
+func addKubernetesServiceToScheme(scheme *runtime.Scheme) error {
+	scheme.AddKnownTypes(GroupVersion,
+		&KubernetesService{},
+		&KubernetesServiceList{})
+	return nil
+}
+
+// +kubebuilder:resource:path=kubernetesservice,shortName=k8s,scope=Namespaced,categories=hypershift-lite
+// +kubebuilder:storageversion
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.version",description="Version of the control plane"
+// +kubebuilder:printcolumn:name="Endpoint",type="string",JSONPath=".status.externalAPIEndpoint.host",description="External API server endpoint"
+// +kubebuilder:printcolumn:name="Available",type="string",JSONPath=".status.conditions[?(@.type==\"Available\")].status",description="Whether the control plane is available"
+// +kubebuilder:printcolumn:name="Expires In",type="string",JSONPath=".status.expiration.remaining",description="Time left until the KubernetesService is deleted",priority=1
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// KubernetesService is the Schema for the KubernetesService API
+type KubernetesService struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KubernetesServiceSpec   `json:"spec,omitempty"`
+	Status KubernetesServiceStatus `json:"status,omitempty"`
+}
+
+// KubernetesServiceSpec defines the desired state of KubernetesService
+type KubernetesServiceSpec struct {
+	// Release specifies the OpenShift release the control plane runs.
+	Release ReleaseSpec `json:"release"`
+
+	// Networking specifies the network configuration of the hosted cluster and
+	// how the API server is exposed.
+	// +optional
+	Networking NetworkingSpec `json:"networking,omitempty"`
+
+	// Components specifies the settings of the control plane components.
+	// +optional
+	Components ComponentsSpec `json:"components,omitempty"`
+
+	// Security specifies how clients are authenticated and authorized, how
+	// requests are audited and how secrets are stored.
+	// +optional
+	Security SecuritySpec `json:"security,omitempty"`
+
+	// Lifecycle specifies how the KubernetesService is managed over its
+	// lifetime.
+	// +optional
+	Lifecycle LifecycleSpec `json:"lifecycle,omitempty"`
+
+	// ControlPlaneNamespace specifies the namespace the control plane runs in.
+	// Shared runs it in the namespace of the KubernetesService. Dedicated runs
+	// it in a namespace named <namespace>-<name> that is created and deleted
+	// with the KubernetesService, and only makes kubeconfigs available in the
+	// namespace of the KubernetesService, so that users who can create
+	// KubernetesServices cannot read the keys of the control plane. It cannot
+	// be changed once the KubernetesService has been created.
+	// +kubebuilder:validation:Enum=Shared;Dedicated
+	// +kubebuilder:default=Shared
+	// +optional
+	ControlPlaneNamespace ControlPlaneNamespaceMode `json:"controlPlaneNamespace,omitempty"`
+
+	// Source specifies the etcd data the control plane is created from, to
+	// make a copy of another control plane. The copy gets its own PKI and
+	// service account keys. It cannot be changed once the KubernetesService
+	// has been created.
+	// +optional
+	Source *SourceSpec `json:"source,omitempty"`
+}
+
+// SourceSpec specifies the etcd data a control plane is created from. Exactly
+// one of its fields must be set. Both require spec.lifecycle.snapshotStorage.
+type SourceSpec struct {
+	// FromKubernetesService copies a KubernetesService in the same namespace as
+	// it is when the copy is created, through an etcd snapshot saved to the
+	// snapshot storage of the copy. A KubernetesService that encrypts secrets
+	// cannot be copied.
+	// +optional
+	FromKubernetesService *corev1.LocalObjectReference `json:"fromKubernetesService,omitempty"`
+
+	// FromSnapshot restores an etcd snapshot from the snapshot storage, such
+	// as the final snapshot of a deleted KubernetesService.
+	// +optional
+	FromSnapshot *SnapshotSource `json:"fromSnapshot,omitempty"`
+}
+
+// SnapshotSource references an etcd snapshot.
+type SnapshotSource struct {
+	// Path is the location of the snapshot in the snapshot storage,
+	// <bucket>/<key>, as reported in events and status.
+	Path string `json:"path"`
+}
+
+// ReleaseSpec specifies an OpenShift release.
+type ReleaseSpec struct {
+	// Image is the pull spec of the release image to use for the API server
+	// components.
+	Image string `json:"image"`
+
+	// PullSecret is a local reference to a secret used to pull OpenShift images
+	PullSecret corev1.LocalObjectReference `json:"pullSecret"`
+}
+
+// NetworkingSpec specifies the network configuration of the hosted cluster and
+// how the API server is exposed.
+type NetworkingSpec struct {
+	// The network configuration of the hosted cluster cannot be changed once
+	// the KubernetesService has been created.
+	ClusterNetworkSpec `json:",inline"`
+
+	// APIServerPublishing specifies how the API server is exposed to clients
+	// outside of the management cluster.
+	// +optional
+	APIServerPublishing APIServerPublishingSpec `json:"apiServerPublishing,omitempty"`
+}
+
+// ComponentsSpec holds the settings of each control plane component.
+type ComponentsSpec struct {
+	// AvailabilityPolicy specifies the availability policy applied to the
+	// control plane components. HighlyAvailable runs multiple replicas of etcd,
+	// the API server and the controller manager spread across hosts and zones
+	// and protected by PodDisruptionBudgets.
+	// +kubebuilder:validation:Enum=SingleReplica;HighlyAvailable
+	// +kubebuilder:default=SingleReplica
+	// +optional
+	AvailabilityPolicy AvailabilityPolicy `json:"availabilityPolicy,omitempty"`
+
+	// FeatureGates enables or disables Kubernetes feature gates in the API
+	// server and the controller manager. Gates set here take precedence over
+	// the defaults.
+	// +optional
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+
+	// KubeAPIServer specifies settings of the Kubernetes API server.
+	// +optional
+	KubeAPIServer KubeAPIServerSpec `json:"kubeAPIServer,omitempty"`
+
+	// KubeControllerManager specifies settings of the Kubernetes controller
+	// manager.
+	// +optional
+	KubeControllerManager KubeControllerManagerSpec `json:"kubeControllerManager,omitempty"`
+
+	// Etcd specifies settings for the etcd member pods. TopologySpreadConstraints
+	// and PriorityClassName are not supported by the etcd operator and must not
+	// be set. Changes only apply to members created after the change.
+	// +optional
+	Etcd ComponentSpec `json:"etcd,omitempty"`
+
+	// EtcdOperator specifies settings for the etcd operator pod.
+	// +optional
+	EtcdOperator ComponentSpec `json:"etcdOperator,omitempty"`
+}
+
+// KubeAPIServerSpec specifies settings of the Kubernetes API server.
+type KubeAPIServerSpec struct {
+	// Scheduling and resource settings of the API server pods.
+	ComponentSpec `json:",inline"`
+
+	// ServingCerts are additional certificates the API server serves for
+	// specific host names, selected with SNI. Requests for any other name,
+	// including in-cluster names, are served with the internally generated
+	// certificate.
+	// +optional
+	ServingCerts []APIServerNamedServingCert `json:"servingCerts,omitempty"`
+
+	// ExtraArgs are additional API server arguments, keyed by flag name without
+	// the leading dashes. They take precedence over the default arguments.
+	// Arguments managed by the operator, such as certificate paths and etcd
+	// endpoints, cannot be set.
+	// +optional
+	ExtraArgs map[string]string `json:"extraArgs,omitempty"`
+
+	// Admission specifies the admission plugins of the API server.
+	// +optional
+	Admission AdmissionSpec `json:"admission,omitempty"`
+}
+
+// KubeControllerManagerSpec specifies settings of the Kubernetes controller
+// manager.
+type KubeControllerManagerSpec struct {
+	// Scheduling and resource settings of the controller manager pods.
+	ComponentSpec `json:",inline"`
+
+	// ExtraArgs are additional controller manager arguments, keyed by flag name
+	// without the leading dashes. They take precedence over the default
+	// arguments. Arguments managed by the operator, such as certificate paths
+	// and kubeconfigs, cannot be set.
+	// +optional
+	ExtraArgs map[string]string `json:"extraArgs,omitempty"`
+}
+
+// SecuritySpec specifies the security settings of the API server.
+type SecuritySpec struct {
+	// Audit specifies the audit policy of the API server.
+	// +optional
+	Audit AuditSpec `json:"audit,omitempty"`
+
+	// Authentication specifies additional ways for clients to authenticate to
+	// the API server. Client certificates signed by the root CA are always
+	// accepted.
+	// +optional
+	Authentication AuthenticationSpec `json:"authentication,omitempty"`
+
+	// Authorization specifies additional authorizers of the API server.
+	// +optional
+	Authorization AuthorizationSpec `json:"authorization,omitempty"`
+
+	// SecretEncryption enables encryption at rest of the secrets of the hosted
+	// cluster. It cannot be removed once set.
+	// +optional
+	SecretEncryption *SecretEncryptionSpec `json:"secretEncryption,omitempty"`
+}
+
+// LifecycleSpec specifies how the KubernetesService is managed over its
+// lifetime.
+type LifecycleSpec struct {
+	// DeletionPolicy specifies what happens to the control plane when the
+	// KubernetesService is deleted. Delete stops the controller manager, then
+	// the API server, and removes etcd and the rest of the control plane.
+	// Snapshot does the same, saving a final etcd snapshot to SnapshotStorage
+	// once the API server is stopped. Orphan leaves the control plane running
+	// and only removes the KubernetesService.
+	// +kubebuilder:validation:Enum=Snapshot;Delete;Orphan
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// SnapshotStorage specifies where etcd snapshots of the control plane are
+	// stored. It is required when DeletionPolicy is Snapshot.
+	// +optional
+	SnapshotStorage *SnapshotStorageSpec `json:"snapshotStorage,omitempty"`
+
+	// PowerState specifies whether the control plane runs. Hibernating saves
+	// an etcd snapshot to SnapshotStorage and stops all components, keeping
+	// the PKI and other secrets of the control plane. Setting it back to
+	// Running restores etcd from the snapshot and starts the components
+	// again.
+	// +kubebuilder:validation:Enum=Running;Hibernating
+	// +kubebuilder:default=Running
+	// +optional
+	PowerState PowerState `json:"powerState,omitempty"`
+
+	// PausedUntil pauses reconciliation of the KubernetesService, either
+	// until the given RFC3339 timestamp or, if set to "true", until it is
+	// removed. While paused, the operator keeps reporting status but does not
+	// change the control plane, so that it can be modified by hand.
+	// +optional
+	PausedUntil string `json:"pausedUntil,omitempty"`
+
+	// Lifetime is how long after its creation the KubernetesService is
+	// deleted by the operator, for ephemeral control planes. It cannot be set
+	// together with ExpiresAt.
+	// +optional
+	Lifetime *metav1.Duration `json:"lifetime,omitempty"`
+
+	// ExpiresAt is when the KubernetesService is deleted by the operator. It
+	// cannot be set together with Lifetime.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+
+	// ExpiryWarnings are how long before the KubernetesService expires the
+	// operator emits a warning event. Defaults to 1h and 10m.
+	// +optional
+	ExpiryWarnings []metav1.Duration `json:"expiryWarnings,omitempty"`
+}
+
+// DeletionPolicy specifies what happens to the control plane when the
+// KubernetesService is deleted.
+type DeletionPolicy string
+
+// PowerState specifies whether the control plane runs.
+type PowerState string
+
+const (
+	RunningPowerState     PowerState = "Running"
+	HibernatingPowerState PowerState = "Hibernating"
+)
+
+const (
+	SnapshotDeletionPolicy DeletionPolicy = "Snapshot"
+	DeleteDeletionPolicy   DeletionPolicy = "Delete"
+	OrphanDeletionPolicy   DeletionPolicy = "Orphan"
+)
+
+// SnapshotStorageSpec specifies where etcd snapshots are stored.
+type SnapshotStorageSpec struct {
+	// S3 stores snapshots in an S3 compatible object store.
+	S3 S3SnapshotStorage `json:"s3"`
+}
+
+// S3SnapshotStorage specifies a bucket of an S3 compatible object store.
+// Snapshots are stored under <prefix>/<namespace>/<name>/ in the bucket.
+type S3SnapshotStorage struct {
+	// Bucket is the name of the bucket.
+	Bucket string `json:"bucket"`
+
+	// Prefix is prepended to the keys of the snapshots.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Endpoint is the URL of the object store. Defaults to AWS S3.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// ForcePathStyle addresses the bucket in the path of requests rather than
+	// in the hostname, for object stores that do not support the latter.
+	// +optional
+	ForcePathStyle bool `json:"forcePathStyle,omitempty"`
+
+	// Credentials references a secret in the namespace of the
+	// KubernetesService with the AWS credentials file in its `credentials` key
+	// and, optionally, the AWS config file in its `config` key. The default
+	// profile is used.
+	Credentials corev1.LocalObjectReference `json:"credentials"`
+}
+
+// AdmissionSpec specifies the admission plugins enabled in the API server and
+// their configuration.
+type AdmissionSpec struct {
+	// Profile is the base set of admission plugins. OpenShift enables the
+	// Kubernetes and OpenShift plugins of an OpenShift cluster, Upstream only
+	// the Kubernetes plugins, and Minimal only the plugins required for a
+	// functional cluster.
+	// +kubebuilder:validation:Enum=OpenShift;Upstream;Minimal
+	// +kubebuilder:default=OpenShift
+	// +optional
+	Profile AdmissionProfileType `json:"profile,omitempty"`
+
+	// EnabledPlugins are admission plugins enabled in addition to those of the
+	// profile.
+	// +optional
+	EnabledPlugins []string `json:"enabledPlugins,omitempty"`
+
+	// DisabledPlugins are admission plugins of the profile that are disabled.
+	// +optional
+	DisabledPlugins []string `json:"disabledPlugins,omitempty"`
+
+	// PluginConfig is the configuration of individual admission plugins. It
+	// replaces any configuration the operator provides for the same plugin.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	PluginConfig []AdmissionPluginConfig `json:"pluginConfig,omitempty"`
+}
+
+// AdmissionPluginConfig holds the configuration of an admission plugin.
+type AdmissionPluginConfig struct {
+	// Name is the name of the admission plugin.
+	Name string `json:"name"`
+
+	// Configuration is the configuration object of the admission plugin.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Configuration runtime.RawExtension `json:"configuration"`
+}
+
+// AdmissionProfileType is a predefined set of admission plugins.
+type AdmissionProfileType string
+
+const (
+	// OpenShiftAdmissionProfile enables the admission plugins of an OpenShift
+	// cluster.
+	OpenShiftAdmissionProfile AdmissionProfileType = "OpenShift"
+
+	// UpstreamAdmissionProfile enables the admission plugins of a Kubernetes
+	// cluster, without any OpenShift plugins.
+	UpstreamAdmissionProfile AdmissionProfileType = "Upstream"
+
+	// MinimalAdmissionProfile only enables the admission plugins required for
+	// a functional cluster.
+	MinimalAdmissionProfile AdmissionProfileType = "Minimal"
+)
+
+// APIServerNamedServingCert maps a certificate to the host names it is served for.
+type APIServerNamedServingCert struct {
+	// Names are the host names, optionally with wildcards, for which the
+	// certificate is served. When empty, the names in the certificate are used.
+	// +optional
+	Names []string `json:"names,omitempty"`
+
+	// ServingCertificate references a kubernetes.io/tls secret in the namespace
+	// of the KubernetesService. If the secret contains a ca.crt key, it is used
+	// as the certificate authority of the external kubeconfig when the
+	// certificate is served for the published API server host name.
+	ServingCertificate corev1.LocalObjectReference `json:"servingCertificate"`
+}
+
+// AuthenticationSpec specifies additional authenticators of the API server.
+type AuthenticationSpec struct {
+	// OIDC configures the API server to accept ID tokens issued by an OpenID
+	// Connect provider.
+	// +optional
+	OIDC *OIDCSpec `json:"oidc,omitempty"`
+
+	// Webhook configures the API server to authenticate bearer tokens with a
+	// remote TokenReview service.
+	// +optional
+	Webhook *WebhookTokenAuthenticatorSpec `json:"webhook,omitempty"`
+}
+
+// WebhookTokenAuthenticatorSpec specifies a remote token authentication
+// service.
+type WebhookTokenAuthenticatorSpec struct {
+	// KubeConfig references a secret in the namespace of the KubernetesService
+	// holding a kubeconfig under the kubeconfig key that describes how to
+	// reach the service.
+	KubeConfig corev1.LocalObjectReference `json:"kubeConfig"`
+
+	// CacheTTL is how long authentication responses are cached. Defaults to 2m.
+	// +optional
+	CacheTTL *metav1.Duration `json:"cacheTTL,omitempty"`
+}
+
+// AuthorizationSpec specifies additional authorizers of the API server.
+type AuthorizationSpec struct {
+	// Webhook configures the API server to authorize requests that are not
+	// allowed by the built-in authorizers with a remote SubjectAccessReview
+	// service.
+	// +optional
+	Webhook *WebhookAuthorizerSpec `json:"webhook,omitempty"`
+}
+
+// WebhookAuthorizerSpec specifies a remote authorization service.
+type WebhookAuthorizerSpec struct {
+	// KubeConfig references a secret in the namespace of the KubernetesService
+	// holding a kubeconfig under the kubeconfig key that describes how to
+	// reach the service.
+	KubeConfig corev1.LocalObjectReference `json:"kubeConfig"`
+
+	// AuthorizedTTL is how long authorized responses are cached. Defaults to 5m.
+	// +optional
+	AuthorizedTTL *metav1.Duration `json:"authorizedTTL,omitempty"`
+
+	// UnauthorizedTTL is how long unauthorized responses are cached. Defaults
+	// to 30s.
+	// +optional
+	UnauthorizedTTL *metav1.Duration `json:"unauthorizedTTL,omitempty"`
+}
+
+// OIDCSpec specifies an OpenID Connect provider trusted by the API server.
+type OIDCSpec struct {
+	// IssuerURL is the URL of the provider. It must use the https scheme.
+	// +kubebuilder:validation:Pattern=`^https://`
+	IssuerURL string `json:"issuerURL"`
+
+	// ClientID is the client ID that all tokens must be issued for.
+	ClientID string `json:"clientID"`
+
+	// UsernameClaim is the claim used as the user name. Defaults to sub.
+	// +optional
+	UsernameClaim string `json:"usernameClaim,omitempty"`
+
+	// UsernamePrefix is prepended to user names to prevent clashes with other
+	// authentication strategies.
+	// +optional
+	UsernamePrefix string `json:"usernamePrefix,omitempty"`
+
+	// GroupsClaim is the claim used as the user's groups.
+	// +optional
+	GroupsClaim string `json:"groupsClaim,omitempty"`
+
+	// GroupsPrefix is prepended to group names to prevent clashes with other
+	// authentication strategies.
+	// +optional
+	GroupsPrefix string `json:"groupsPrefix,omitempty"`
+
+	// CA references a secret in the namespace of the KubernetesService holding
+	// the certificate authority bundle of the provider under the ca.crt key.
+	// When not set, the system trust store is used.
+	// +optional
+	CA *corev1.LocalObjectReference `json:"ca,omitempty"`
+}
+
+// SecretEncryptionSpec specifies how secrets are encrypted at rest.
+type SecretEncryptionSpec struct {
+	// Type is the encryption provider used for new keys. Changing it rotates
+	// the encryption key.
+	// +kubebuilder:validation:Enum=aescbc;aesgcm;secretbox;kms
+	// +kubebuilder:default=aescbc
+	// +optional
+	Type SecretEncryptionType `json:"type,omitempty"`
+
+	// RotationInterval is how often the encryption key is rotated. Keys are
+	// not rotated when it is not set.
+	// +optional
+	RotationInterval *metav1.Duration `json:"rotationInterval,omitempty"`
+
+	// KMS specifies the KMS plugin used for envelope encryption. It is
+	// required when type is kms.
+	// +optional
+	KMS *KMSSpec `json:"kms,omitempty"`
+}
+
+// KMSSpec specifies a KMS plugin that runs as a sidecar of the API server and
+// serves the KMS v1beta1 API on a unix socket. The path of the socket is passed
+// to the plugin in the KMS_PLUGIN_SOCKET environment variable.
+type KMSSpec struct {
+	// Image is the image of the KMS plugin.
+	Image string `json:"image"`
+
+	// Command overrides the entrypoint of the image.
+	// +optional
+	Command []string `json:"command,omitempty"`
+
+	// Args are the arguments of the KMS plugin.
+	// +optional
+	Args []string `json:"args,omitempty"`
+
+	// Env are additional environment variables of the KMS plugin.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Credentials references a secret in the namespace of the
+	// KubernetesService that is mounted in the KMS plugin container at
+	// /etc/kms-plugin/credentials.
+	// +optional
+	Credentials *corev1.LocalObjectReference `json:"credentials,omitempty"`
+
+	// Resources are the compute resources of the KMS plugin container.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// CacheSize is the number of data encryption keys cached in memory by the
+	// API server. Defaults to 1000.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	CacheSize *int32 `json:"cacheSize,omitempty"`
+
+	// Timeout is how long the API server waits for the KMS plugin to respond.
+	// Defaults to 3s.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// SecretEncryptionType is an encryption provider of the API server.
+type SecretEncryptionType string
+
+const (
+	// AESCBCEncryption encrypts with AES-CBC and PKCS#7 padding.
+	AESCBCEncryption SecretEncryptionType = "aescbc"
+
+	// AESGCMEncryption encrypts with AES-GCM. Keys should be rotated
+	// frequently when it is used.
+	AESGCMEncryption SecretEncryptionType = "aesgcm"
+
+	// SecretboxEncryption encrypts with XSalsa20 and Poly1305.
+	SecretboxEncryption SecretEncryptionType = "secretbox"
+
+	// KMSEncryption encrypts with data encryption keys that are in turn
+	// encrypted by a KMS plugin.
+	KMSEncryption SecretEncryptionType = "kms"
+)
+
+// AuditSpec specifies the audit policy of the API server.
+type AuditSpec struct {
+	// Profile is the audit policy profile used when no custom policy is
+	// specified.
+	// +kubebuilder:validation:Enum=None;Default;WriteRequestBodies;AllRequestBodies
+	// +kubebuilder:default=Default
+	// +optional
+	Profile AuditProfileType `json:"profile,omitempty"`
+
+	// CustomPolicy references a ConfigMap in the namespace of the
+	// KubernetesService holding an audit.k8s.io Policy under the policy.yaml
+	// key. When set, it is used instead of the profile.
+	// +optional
+	CustomPolicy *corev1.LocalObjectReference `json:"customPolicy,omitempty"`
+}
+
+// AuditProfileType is a predefined audit policy.
+type AuditProfileType string
+
+const (
+	// NoneAuditProfile disables auditing.
+	NoneAuditProfile AuditProfileType = "None"
+
+	// DefaultAuditProfile logs the metadata of all requests.
+	DefaultAuditProfile AuditProfileType = "Default"
+
+	// WriteRequestBodiesAuditProfile additionally logs the request and
+	// response bodies of write requests.
+	WriteRequestBodiesAuditProfile AuditProfileType = "WriteRequestBodies"
+
+	// AllRequestBodiesAuditProfile additionally logs the request and response
+	// bodies of all requests.
+	AllRequestBodiesAuditProfile AuditProfileType = "AllRequestBodies"
+)
+
+// PublishingStrategyType is a way to expose the API server outside of the
+// management cluster.
+type PublishingStrategyType string
+
+const (
+	// ClusterIPPublishing only exposes the API server within the management
+	// cluster through a ClusterIP service.
+	ClusterIPPublishing PublishingStrategyType = "ClusterIP"
+
+	// NodePortPublishing exposes the API server through a NodePort service.
+	NodePortPublishing PublishingStrategyType = "NodePort"
+
+	// LoadBalancerPublishing exposes the API server through a LoadBalancer service.
+	LoadBalancerPublishing PublishingStrategyType = "LoadBalancer"
+
+	// RoutePublishing exposes the API server through an OpenShift passthrough Route.
+	RoutePublishing PublishingStrategyType = "Route"
+
+	// IngressPublishing exposes the API server through an Ingress. The ingress
+	// controller must support TLS passthrough.
+	IngressPublishing PublishingStrategyType = "Ingress"
+)
+
+// APIServerPublishingSpec specifies how the API server is published.
+type APIServerPublishingSpec struct {
+	// Type is the publishing strategy used for the API server.
+	// +kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer;Route;Ingress
+	// +kubebuilder:default=ClusterIP
+	// +optional
+	Type PublishingStrategyType `json:"type,omitempty"`
+
+	// Hostname is the external name or IP address clients use to reach the API
+	// server. It is required for NodePort, where it is the address of a node, and
+	// for Ingress. For Route it defaults to the host generated by the router and
+	// for LoadBalancer to the address of the load balancer.
+	// +optional
+	Hostname string `json:"hostname,omitempty"`
+
+	// NodePort is the node port to use with the NodePort strategy. One is
+	// allocated when not set.
+	// +optional
+	NodePort int32 `json:"nodePort,omitempty"`
+
+	// IngressClassName is the ingress class used with the Ingress strategy.
+	// +optional
+	IngressClassName *string `json:"ingressClassName,omitempty"`
+}
+
+// ComponentSpec specifies scheduling and resource settings for the pods of a
+// control plane component.
+type ComponentSpec struct {
+	// Resources are the compute resources of the component's main container.
+	// When not set, the operator applies default requests.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// NodeSelector restricts the nodes the component's pods can run on.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations are applied to the component's pods.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// TopologySpreadConstraints control how the component's pods are spread
+	// across topology domains.
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// PriorityClassName is the name of the priority class of the component's pods.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
+
+// AvailabilityPolicy specifies a high level availability policy for components.
+type AvailabilityPolicy string
+
+const (
+	// SingleReplica means components run a single replica.
+	SingleReplica AvailabilityPolicy = "SingleReplica"
+
+	// HighlyAvailable means components run with multiple replicas that
+	// tolerate the loss of a node or a zone.
+	HighlyAvailable AvailabilityPolicy = "HighlyAvailable"
+)
+
+// ClusterNetworkSpec specifies the network ranges and DNS domain used by the
+// hosted cluster. Unset fields are defaulted by the operator.
+type ClusterNetworkSpec struct {
+	// ServiceCIDR is the IP range from which service cluster IPs are allocated.
+	// Defaults to 172.30.0.0/16.
+	// +kubebuilder:validation:Format=cidr
+	// +optional
+	ServiceCIDR string `json:"serviceCIDR,omitempty"`
+
+	// PodCIDR is the IP range from which pod IPs are allocated.
+	// Defaults to 10.128.0.0/14.
+	// +kubebuilder:validation:Format=cidr
+	// +optional
+	PodCIDR string `json:"podCIDR,omitempty"`
+
+	// ClusterDomain is the DNS domain of the hosted cluster.
+	// Defaults to cluster.local.
+	// +optional
+	ClusterDomain string `json:"clusterDomain,omitempty"`
+
+	// AdvertiseAddress is the IP address the API server advertises to members
+	// of the hosted cluster. It must not fall within the service or pod CIDR.
+	// Defaults to 172.20.0.1.
+	// +kubebuilder:validation:Format=ipv4
+	// +optional
+	AdvertiseAddress string `json:"advertiseAddress,omitempty"`
+}
+
+// ControlPlaneNamespaceMode specifies the namespace the control plane runs in.
+type ControlPlaneNamespaceMode string
+
+const (
+	// SharedNamespace runs the control plane in the namespace of the
+	// KubernetesService.
+	SharedNamespace ControlPlaneNamespaceMode = "Shared"
+
+	// DedicatedNamespace runs the control plane in a namespace of its own.
+	DedicatedNamespace ControlPlaneNamespaceMode = "Dedicated"
+)
+
+// KubernetesServiceStatus defines the observed state of KubernetesService
+type KubernetesServiceStatus struct {
+	// Conditions contains details of the current state of the KubernetesService
+	// +kubebuilder:validation:Required
+	Conditions []KubernetesServiceCondition `json:"conditions"`
+
+	// ObservedGeneration is the generation of the KubernetesService last
+	// processed by the operator.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// ControlPlaneNamespace is the namespace the control plane runs in.
+	// +optional
+	ControlPlaneNamespace string `json:"controlPlaneNamespace,omitempty"`
+
+	// Version is the OpenShift version of the release image of the control
+	// plane.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// ComponentVersions are the versions of the components of the release
+	// image, keyed by component name.
+	// +optional
+	ComponentVersions map[string]string `json:"componentVersions,omitempty"`
+
+	// ReleaseImageDigest is the digest of the release image of the control
+	// plane, when known.
+	// +optional
+	ReleaseImageDigest string `json:"releaseImageDigest,omitempty"`
+
+	// InternalAPIEndpoint is the endpoint clients within the management
+	// cluster use to reach the API server.
+	// +optional
+	InternalAPIEndpoint *APIEndpoint `json:"internalAPIEndpoint,omitempty"`
+
+	// Networking is the network configuration the control plane was created
+	// with. Changes to spec.networking that differ from it are rejected.
+	// +optional
+	Networking *ClusterNetworkSpec `json:"networking,omitempty"`
+
+	// ExternalAPIEndpoint is the endpoint clients outside of the management
+	// cluster use to reach the API server, when it is published.
+	// +optional
+	ExternalAPIEndpoint *APIEndpoint `json:"externalAPIEndpoint,omitempty"`
+
+	// Kubeconfigs references the secrets holding system:admin kubeconfigs for
+	// the hosted cluster.
+	// +optional
+	Kubeconfigs KubeconfigSecrets `json:"kubeconfigs,omitempty"`
+
+	// SecretEncryption is the state of the encryption keys of the API server.
+	// +optional
+	SecretEncryption *SecretEncryptionStatus `json:"secretEncryption,omitempty"`
+
+	// Hibernation is set while the control plane is hibernating or resuming.
+	// +optional
+	Hibernation *HibernationStatus `json:"hibernation,omitempty"`
+
+	// Expiration is set when the KubernetesService has a limited lifetime.
+	// +optional
+	Expiration *ExpirationStatus `json:"expiration,omitempty"`
+
+	// Source reports the progress of creating the control plane from
+	// spec.source.
+	// +optional
+	Source *SourceStatus `json:"source,omitempty"`
+
+	// History lists the release images rolled out to the control plane,
+	// most recent first.
+	// +optional
+	History []ReleaseHistory `json:"history,omitempty"`
+
+	// Upgrade is set while the control plane is upgraded to another release
+	// image.
+	// +optional
+	Upgrade *UpgradeStatus `json:"upgrade,omitempty"`
+}
+
+// ReleaseHistory records a release image rolled out to the control plane.
+type ReleaseHistory struct {
+	// State is whether the release image was rolled out completely.
+	State UpgradeState `json:"state"`
+
+	// Version is the OpenShift version of the release image.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Image is the pull spec of the release image.
+	Image string `json:"image"`
+
+	// StartedTime is when the rollout of the release image started.
+	StartedTime metav1.Time `json:"startedTime"`
+
+	// CompletionTime is when the rollout of the release image completed or
+	// was rolled back.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Snapshot is the location of the etcd snapshot saved before the upgrade
+	// to the release image, if any.
+	// +optional
+	Snapshot string `json:"snapshot,omitempty"`
+}
+
+// UpgradeState is the state of a release image in the history of a control
+// plane.
+type UpgradeState string
+
+const (
+	// PartialUpgradeState means the release image is being rolled out.
+	PartialUpgradeState UpgradeState = "Partial"
+
+	// CompletedUpgradeState means every component runs the release image.
+	CompletedUpgradeState UpgradeState = "Completed"
+
+	// RolledBackUpgradeState means the release image failed to roll out and
+	// the control plane was reverted to the previous release image.
+	RolledBackUpgradeState UpgradeState = "RolledBack"
+)
+
+// UpgradeStatus is the progress of an upgrade of the control plane to another
+// release image.
+type UpgradeStatus struct {
+	// Phase is the current step of the upgrade.
+	Phase UpgradePhase `json:"phase"`
+
+	// Image is the release image being rolled out.
+	Image string `json:"image"`
+
+	// PreviousImage is the release image the control plane ran before the
+	// upgrade, which it is reverted to if the upgrade fails.
+	PreviousImage string `json:"previousImage"`
+
+	// PhaseStartTime is when the current phase started.
+	PhaseStartTime metav1.Time `json:"phaseStartTime"`
+}
+
+// UpgradePhase is a step of an upgrade of the control plane.
+type UpgradePhase string
+
+const (
+	// SavingSnapshotUpgradePhase means an etcd snapshot is being saved before
+	// any component is upgraded.
+	SavingSnapshotUpgradePhase UpgradePhase = "SavingSnapshot"
+
+	// KubeAPIServerUpgradePhase means the API server is being rolled out with
+	// the new release image.
+	KubeAPIServerUpgradePhase UpgradePhase = "RollingOutKubeAPIServer"
+
+	// KubeControllerManagerUpgradePhase means the controller manager is being
+	// rolled out with the new release image.
+	KubeControllerManagerUpgradePhase UpgradePhase = "RollingOutKubeControllerManager"
+
+	// RollingBackUpgradePhase means the control plane is being reverted to the
+	// previous release image.
+	RollingBackUpgradePhase UpgradePhase = "RollingBack"
+)
+
+// SourceStatus is the progress of creating a control plane from its source.
+type SourceStatus struct {
+	// Snapshot is the location of the etcd snapshot the control plane is
+	// restored from.
+	Snapshot string `json:"snapshot"`
+
+	// SnapshotTime is when the snapshot of the source KubernetesService was
+	// saved.
+	// +optional
+	SnapshotTime *metav1.Time `json:"snapshotTime,omitempty"`
+
+	// RestoreTime is when etcd was restored from the snapshot.
+	// +optional
+	RestoreTime *metav1.Time `json:"restoreTime,omitempty"`
+
+	// CompletionTime is when the restored cluster was made independent of its
+	// source.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// ExpirationStatus reports when a KubernetesService with a limited lifetime
+// is deleted.
+type ExpirationStatus struct {
+	// ExpiresAt is when the KubernetesService is deleted, including any
+	// extension of its lifetime.
+	ExpiresAt metav1.Time `json:"expiresAt"`
+
+	// Remaining is the time left until the KubernetesService expires, to the
+	// minute.
+	Remaining string `json:"remaining"`
+
+	// LastWarning is the expiry warning that was emitted last. It is reset
+	// when the lifetime is extended past it.
+	// +optional
+	LastWarning *metav1.Duration `json:"lastWarning,omitempty"`
+}
+
+// HibernationStatus is the state of a hibernated control plane.
+type HibernationStatus struct {
+	// Snapshot is the location of the etcd snapshot the control plane is
+	// restored from when it resumes. It is empty if etcd did not exist when
+	// the control plane was hibernated.
+	// +optional
+	Snapshot string `json:"snapshot,omitempty"`
+
+	// SnapshotTime is when the snapshot was saved. It is unset while the
+	// snapshot is being saved.
+	// +optional
+	SnapshotTime *metav1.Time `json:"snapshotTime,omitempty"`
+}
+
+// SecretEncryptionStatus is the state of the encryption keys of the API server.
+type SecretEncryptionStatus struct {
+	// Keys are the encryption keys configured in the API server. Secrets are
+	// written with the first key and read with any of them.
+	// +optional
+	Keys []EncryptionKey `json:"keys,omitempty"`
+
+	// RotationPhase is the current step of a key rotation. It is empty when no
+	// rotation is in progress.
+	// +optional
+	RotationPhase EncryptionKeyRotationPhase `json:"rotationPhase,omitempty"`
+
+	// LastRotationTime is when the last key rotation completed.
+	// +optional
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+}
+
+// EncryptionKey identifies an encryption key of the API server.
+type EncryptionKey struct {
+	// Name is the name of the key.
+	Name string `json:"name"`
+
+	// Type is the encryption provider of the key.
+	Type SecretEncryptionType `json:"type"`
+}
+
+// EncryptionKeyRotationPhase is a step of an encryption key rotation.
+type EncryptionKeyRotationPhase string
+
+const (
+	// EncryptionKeyAdded means a new key has been added to the API server
+	// configuration for reading only, so that every API server instance can
+	// read secrets written with it before it is used.
+	EncryptionKeyAdded EncryptionKeyRotationPhase = "KeyAdded"
+
+	// EncryptionKeyPromoted means the new key is used to write secrets, and
+	// existing secrets are being re-encrypted with it.
+	EncryptionKeyPromoted EncryptionKeyRotationPhase = "KeyPromoted"
+
+	// EncryptionKeySecretsReencrypted means all secrets have been re-encrypted
+	// with the new key, and the old keys are being removed.
+	EncryptionKeySecretsReencrypted EncryptionKeyRotationPhase = "SecretsReencrypted"
+)
+
+// KubeconfigSecrets references secrets in the namespace of the
+// KubernetesService holding kubeconfigs under the kubeconfig key.
+type KubeconfigSecrets struct {
+	// Service is a kubeconfig that reaches the API server through its service.
+	// It can be used by pods in the namespace of the KubernetesService.
+	// +optional
+	Service *corev1.LocalObjectReference `json:"service,omitempty"`
+
+	// Localhost is a kubeconfig that reaches the API server on localhost. It can
+	// be used by containers of the API server pods.
+	// +optional
+	Localhost *corev1.LocalObjectReference `json:"localhost,omitempty"`
+
+	// External is a kubeconfig that reaches the API server at its external
+	// endpoint. It is only set when the API server is published.
+	// +optional
+	External *corev1.LocalObjectReference `json:"external,omitempty"`
+}
+
+// APIEndpoint is an address at which the API server can be reached.
+type APIEndpoint struct {
+	// Host is the hostname or IP address of the endpoint.
+	Host string `json:"host"`
+
+	// Port is the port of the endpoint.
+	Port int32 `json:"port"`
+}
+
+type ConditionType string
+
+const (
+	Available                      ConditionType = "Available"
+	EtcdAvailable                  ConditionType = "EtcdAvailable"
+	KubeAPIServerAvailable         ConditionType = "KubeAPIServerAvailable"
+	KubeControllerManagerAvailable ConditionType = "KubeControllerManagerAvailable"
+	ValidConfiguration             ConditionType = "ValidConfiguration"
+	Degraded                       ConditionType = "Degraded"
+	Deleting                       ConditionType = "Deleting"
+	ReconciliationPaused           ConditionType = "ReconciliationPaused"
+	Hibernating                    ConditionType = "Hibernating"
+	Resuming                       ConditionType = "Resuming"
+	Bound                          ConditionType = "Bound"
+	Restoring                      ConditionType = "Restoring"
+	Upgrading                      ConditionType = "Upgrading"
+	UpgradeBlocked                 ConditionType = "UpgradeBlocked"
+)
+
+// KubernetesServiceCondition contains details of a specific status condition
+type KubernetesServiceCondition struct {
+	// type specifies the aspect reported by this condition.
+	// +kubebuilder:validation:Required
+	Type ConditionType `json:"type"`
+
+	// status of the condition, one of True, False, Unknown.
+	// +kubebuilder:validation:Required
+	Status corev1.ConditionStatus `json:"status"`
+
+	// lastTransitionTime is the time of the last update to the current status property.
+	// +kubebuilder:validation:Required
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+
+	// reason is the CamelCase reason for the condition's current status.
+	// +kubebuilder:validation:Optional
+	Reason string `json:"reason,omitempty"`
+
+	// message provides additional information about the current condition.
+	// This is only to be consumed by humans.  It may contain Line Feed
+	// characters (U+000A), which should be rendered as new lines.
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// KubernetesServiceList contains a list of KubernetesService.
+type KubernetesServiceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KubernetesService `json:"items"`
+}
@@ -0,0 +1,87 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+func init() {
+	SchemeBuilder.Register(addKubernetesServiceClaimToScheme)
+}
+
+func addKubernetesServiceClaimToScheme(scheme *runtime.Scheme) error {
+	scheme.AddKnownTypes(GroupVersion,
+		&KubernetesServiceClaim{},
+		&KubernetesServiceClaimList{})
+	return nil
+}
+
+// +kubebuilder:resource:path=kubernetesserviceclaims,shortName=k8sclaim,scope=Namespaced,categories=hypershift-lite
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Pool",type="string",JSONPath=".spec.pool",description="KubernetesServicePool claimed from"
+// +kubebuilder:printcolumn:name="KubernetesService",type="string",JSONPath=".status.kubernetesService.name",description="KubernetesService bound to the claim"
+// +kubebuilder:printcolumn:name="Available",type="string",JSONPath=".status.conditions[?(@.type==\"Available\")].status",description="Whether the bound control plane is available"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// KubernetesServiceClaim binds a KubernetesService of a KubernetesServicePool
+// and exposes its kubeconfigs in the namespace of the claim. The
+// KubernetesService is deleted with the claim.
+type KubernetesServiceClaim struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KubernetesServiceClaimSpec   `json:"spec,omitempty"`
+	Status KubernetesServiceClaimStatus `json:"status,omitempty"`
+}
+
+// KubernetesServiceClaimSpec defines the desired state of
+// KubernetesServiceClaim
+type KubernetesServiceClaimSpec struct {
+	// Pool is the name of the KubernetesServicePool to claim from.
+	Pool string `json:"pool"`
+
+	// PoolNamespace is the namespace of the KubernetesServicePool. Defaults to
+	// the namespace of the claim. The pool must list the namespace of the
+	// claim in its claimNamespaces when they differ.
+	// +optional
+	PoolNamespace string `json:"poolNamespace,omitempty"`
+
+	// Lifetime is how long after it is bound the KubernetesService is deleted,
+	// even if the claim still exists.
+	// +optional
+	Lifetime *metav1.Duration `json:"lifetime,omitempty"`
+}
+
+// KubernetesServiceClaimStatus defines the observed state of
+// KubernetesServiceClaim
+type KubernetesServiceClaimStatus struct {
+	// Conditions contains details of the current state of the claim
+	// +optional
+	Conditions []KubernetesServiceCondition `json:"conditions,omitempty"`
+
+	// KubernetesService is the KubernetesService bound to the claim, in the
+	// namespace of the pool.
+	// +optional
+	KubernetesService *KubernetesServiceReference `json:"kubernetesService,omitempty"`
+
+	// Kubeconfigs references the secrets in the namespace of the claim holding
+	// system:admin kubeconfigs for the bound KubernetesService. The service
+	// kubeconfig addresses the API server by its fully qualified service name.
+	// +optional
+	Kubeconfigs KubeconfigSecrets `json:"kubeconfigs,omitempty"`
+}
+
+// KubernetesServiceReference references a KubernetesService.
+type KubernetesServiceReference struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// KubernetesServiceClaimList contains a list of KubernetesServiceClaim.
+type KubernetesServiceClaimList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KubernetesServiceClaim `json:"items"`
+}
@@ -0,0 +1,73 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+func init() {
+	SchemeBuilder.Register(addKubernetesServicePoolToScheme)
+}
+
+func addKubernetesServicePoolToScheme(scheme *runtime.Scheme) error {
+	scheme.AddKnownTypes(GroupVersion,
+		&KubernetesServicePool{},
+		&KubernetesServicePoolList{})
+	return nil
+}
+
+// +kubebuilder:resource:path=kubernetesservicepools,shortName=k8spool,scope=Namespaced,categories=hypershift-lite
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Size",type="integer",JSONPath=".spec.size",description="Number of unclaimed KubernetesServices kept in the pool"
+// +kubebuilder:printcolumn:name="Ready",type="integer",JSONPath=".status.ready",description="Number of unclaimed KubernetesServices that are available"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// KubernetesServicePool keeps a number of KubernetesServices of a template
+// running in standby, to be bound to KubernetesServiceClaims without waiting
+// for a control plane to come up.
+type KubernetesServicePool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KubernetesServicePoolSpec   `json:"spec,omitempty"`
+	Status KubernetesServicePoolStatus `json:"status,omitempty"`
+}
+
+// KubernetesServicePoolSpec defines the desired state of KubernetesServicePool
+type KubernetesServicePoolSpec struct {
+	// Size is the number of unclaimed KubernetesServices kept in the pool. A
+	// claimed KubernetesService is replaced with a new one.
+	// +kubebuilder:validation:Minimum=0
+	Size int32 `json:"size"`
+
+	// Template is the spec of the KubernetesServices of the pool, which are
+	// created in the namespace of the pool. Unclaimed KubernetesServices
+	// created from an earlier template are replaced once enough
+	// KubernetesServices of the current template are available.
+	Template KubernetesServiceSpec `json:"template"`
+
+	// ClaimNamespaces are namespaces other than that of the pool whose
+	// KubernetesServiceClaims may claim from the pool.
+	// +optional
+	ClaimNamespaces []string `json:"claimNamespaces,omitempty"`
+}
+
+// KubernetesServicePoolStatus defines the observed state of
+// KubernetesServicePool
+type KubernetesServicePoolStatus struct {
+	// Size is the number of unclaimed KubernetesServices in the pool.
+	Size int32 `json:"size"`
+
+	// Ready is the number of unclaimed KubernetesServices in the pool that are
+	// available.
+	Ready int32 `json:"ready"`
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// KubernetesServicePoolList contains a list of KubernetesServicePool.
+type KubernetesServicePoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KubernetesServicePool `json:"items"`
+}
@@ -0,0 +1,163 @@
+package kubeservice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/etcd"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/kas"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/ks"
+)
+
+// teardownPollInterval is how often the progress of a teardown is checked.
+const teardownPollInterval = 5 * time.Second
+
+// reconcileFinalizer adds the finalizer that lets the operator tear down the
+// control plane before the KubernetesService is removed.
+func (r *KubernetesServiceReconciler) reconcileFinalizer(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService) error {
+	if controllerutil.ContainsFinalizer(kubeSvc, ks.ControlPlaneFinalizer) {
+		return nil
+	}
+	controllerutil.AddFinalizer(kubeSvc, ks.ControlPlaneFinalizer)
+	if err := r.Update(ctx, kubeSvc); err != nil {
+		return fmt.Errorf("failed to add control plane finalizer: %w", err)
+	}
+	return nil
+}
+
+// reconcileDeletion tears down the control plane of a KubernetesService that
+// is being deleted according to its deletion policy, one step per reconcile,
+// and removes the finalizer once done. The controller manager is stopped
+// before the API server so that it does not act on a disappearing API, and
+// the API server is stopped before etcd so that a final snapshot holds all
+// writes. Objects left in the namespace of the KubernetesService are garbage
+// collected through their owner references.
+func (r *KubernetesServiceReconciler) reconcileDeletion(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(kubeSvc, ks.ControlPlaneFinalizer) {
+		return ctrl.Result{}, nil
+	}
+	log := ctrl.LoggerFrom(ctx)
+	namespace, instance := ks.ControlPlaneNamespace(kubeSvc), ks.InstanceName(kubeSvc)
+	policy := ks.DeletionPolicy(kubeSvc)
+
+	// A copy deleted while saving its source leaves a backup next to it
+	if source := kubeSvc.Status.Source; source != nil && source.SnapshotTime == nil {
+		if err := r.cleanupSourceSnapshot(ctx, kubeSvc); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if policy == hyperlitev1.OrphanDeletionPolicy {
+		if err := r.setProgressCondition(ctx, kubeSvc, hyperlitev1.Deleting, "OrphaningControlPlane", "Releasing the control plane from the KubernetesService"); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.orphanControlPlane(ctx, kubeSvc); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, r.removeFinalizer(ctx, kubeSvc)
+	}
+
+	if stopped, err := r.stopAPIServer(ctx, kubeSvc, hyperlitev1.Deleting); err != nil || !stopped {
+		return ctrl.Result{RequeueAfter: teardownPollInterval}, err
+	}
+
+	etcdCluster := etcd.Cluster(namespace, instance)
+	if err := r.Get(ctx, client.ObjectKeyFromObject(etcdCluster), etcdCluster); err != nil && !apierrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+		return ctrl.Result{}, fmt.Errorf("cannot get etcd cluster: %w", err)
+	} else if err == nil {
+		if policy == hyperlitev1.SnapshotDeletionPolicy && etcdCluster.DeletionTimestamp.IsZero() {
+			if hibernation := kubeSvc.Status.Hibernation; hibernation != nil && hibernation.SnapshotTime != nil {
+				// Etcd is stopped and its data is in the hibernation snapshot
+				r.recorder.Eventf(kubeSvc, corev1.EventTypeNormal, "SnapshotSaved", "Etcd was hibernated, its final snapshot is %s", hibernation.Snapshot)
+			} else {
+				done, err := r.reconcileFinalSnapshot(ctx, kubeSvc)
+				if err != nil || !done {
+					return ctrl.Result{RequeueAfter: teardownPollInterval}, err
+				}
+			}
+		}
+		if err := r.setProgressCondition(ctx, kubeSvc, hyperlitev1.Deleting, "DeletingEtcd", "Waiting for the etcd cluster to be removed"); err != nil {
+			return ctrl.Result{}, err
+		}
+		if etcdCluster.DeletionTimestamp.IsZero() {
+			log.Info("Deleting etcd cluster")
+			if err := r.Delete(ctx, etcdCluster); err != nil && !apierrors.IsNotFound(err) {
+				return ctrl.Result{}, fmt.Errorf("failed to delete etcd cluster: %w", err)
+			}
+		}
+		return ctrl.Result{RequeueAfter: teardownPollInterval}, nil
+	}
+
+	if ks.IsDedicatedNamespace(kubeSvc) {
+		if err := r.setProgressCondition(ctx, kubeSvc, hyperlitev1.Deleting, "DeletingNamespace", "Waiting for the control plane namespace to be removed"); err != nil {
+			return ctrl.Result{}, err
+		}
+		if deleted, err := r.deleteControlPlaneNamespace(ctx, kubeSvc); err != nil || !deleted {
+			return ctrl.Result{RequeueAfter: teardownPollInterval}, err
+		}
+	}
+	log.Info("Control plane torn down")
+	return ctrl.Result{}, r.removeFinalizer(ctx, kubeSvc)
+}
+
+// reconcileFinalSnapshot saves a snapshot of etcd to the snapshot storage of
+// the KubernetesService and returns true once it has been saved. Changing the
+// deletion policy to Delete gives up on a snapshot that keeps failing.
+func (r *KubernetesServiceReconciler) reconcileFinalSnapshot(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService) (bool, error) {
+	storage := kubeSvc.Spec.Lifecycle.SnapshotStorage
+	if storage == nil {
+		return false, r.setProgressCondition(ctx, kubeSvc, hyperlitev1.Deleting, "SnapshotFailed", "Cannot save a final etcd snapshot: snapshotStorage is not configured")
+	}
+	snapshotPath := etcd.SnapshotPath(storage, kubeSvc, fmt.Sprintf("%s-%s", etcd.FinalSnapshot, kubeSvc.DeletionTimestamp.UTC().Format(snapshotTimeFormat)))
+	saved, err := r.reconcileSnapshot(ctx, kubeSvc, etcd.FinalSnapshot, snapshotPath, hyperlitev1.Deleting)
+	if saved {
+		r.recorder.Eventf(kubeSvc, corev1.EventTypeNormal, "SnapshotSaved", "Saved final etcd snapshot to %s", snapshotPath)
+	}
+	return saved, err
+}
+
+// orphanControlPlane removes the KubernetesService from the owners of its
+// control plane objects so that they are not garbage collected. A
+// KubernetesService created again with the same name adopts them.
+func (r *KubernetesServiceReconciler) orphanControlPlane(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService) error {
+	instance := ks.InstanceName(kubeSvc)
+	objects := controlPlaneObjects(ks.ControlPlaneNamespace(kubeSvc), instance)
+	if ks.IsDedicatedNamespace(kubeSvc) {
+		objects = append(objects, kas.ServiceKubeconfigSecret(kubeSvc.Namespace, instance), kas.ExternalKubeconfigSecret(kubeSvc.Namespace, instance))
+	}
+	for _, object := range objects {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(object), object); err != nil {
+			if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+				continue
+			}
+			return fmt.Errorf("cannot get %s: %w", object.GetName(), err)
+		}
+		ownerRefs := removeKSOwnerRef(kubeSvc, object.GetOwnerReferences())
+		if len(ownerRefs) == len(object.GetOwnerReferences()) {
+			continue
+		}
+		object.SetOwnerReferences(ownerRefs)
+		if err := r.Update(ctx, object); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to orphan %s: %w", object.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// removeFinalizer lets the KubernetesService be removed.
+func (r *KubernetesServiceReconciler) removeFinalizer(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService) error {
+	controllerutil.RemoveFinalizer(kubeSvc, ks.ControlPlaneFinalizer)
+	if err := r.Update(ctx, kubeSvc); err != nil {
+		return fmt.Errorf("failed to remove control plane finalizer: %w", err)
+	}
+	return nil
+}
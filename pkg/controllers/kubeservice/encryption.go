@@ -0,0 +1,211 @@
+package kubeservice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/kas"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/ks"
+)
+
+const reencryptPageSize = 500
+
+// reconcileSecretEncryption renders the encryption configuration of the API
+// server from the keys recorded in the status. The first key is recorded when
+// encryption is enabled, and existing secrets are then re-encrypted with it. It
+// returns nil when encryption is not enabled.
+func (r *KubernetesServiceReconciler) reconcileSecretEncryption(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService) (*corev1.Secret, error) {
+	if kubeSvc.Spec.Security.SecretEncryption == nil {
+		return nil, nil
+	}
+	status := kubeSvc.Status.SecretEncryption
+	if status == nil || len(status.Keys) == 0 {
+		now := metav1.Now()
+		kubeSvc.Status.SecretEncryption = &hyperlitev1.SecretEncryptionStatus{
+			Keys: []hyperlitev1.EncryptionKey{
+				{
+					Name: kas.NewEncryptionKeyName(now),
+					Type: encryptionType(kubeSvc.Spec.Security.SecretEncryption),
+				},
+			},
+			RotationPhase:    hyperlitev1.EncryptionKeyPromoted,
+			LastRotationTime: &now,
+		}
+		if err := r.Status().Update(ctx, kubeSvc); err != nil {
+			return nil, fmt.Errorf("failed to record initial encryption key: %w", err)
+		}
+	}
+
+	encryptionConfig := kas.EncryptionConfigSecret(ks.ControlPlaneNamespace(kubeSvc), ks.InstanceName(kubeSvc))
+	if _, err := controllerutil.CreateOrUpdate(ctx, r, encryptionConfig, func() error {
+		ensureKSOwnerRef(kubeSvc, encryptionConfig)
+		return kas.ReconcileEncryptionConfigSecret(encryptionConfig, kubeSvc.Status.SecretEncryption.Keys, kubeSvc.Spec.Security.SecretEncryption.KMS)
+	}); err != nil {
+		return nil, fmt.Errorf("failed to reconcile encryption config: %w", err)
+	}
+	return encryptionConfig, nil
+}
+
+// reconcileSecretEncryptionRotation advances the rotation of the encryption
+// key once the API server runs with the configuration of the current step. A
+// new key is first added for reading only, then used for writing, existing
+// secrets are re-encrypted with it, and the old keys are finally removed.
+func (r *KubernetesServiceReconciler) reconcileSecretEncryptionRotation(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService) (ctrl.Result, error) {
+	spec := kubeSvc.Spec.Security.SecretEncryption
+	status := kubeSvc.Status.SecretEncryption
+	if spec == nil || status == nil || len(status.Keys) == 0 {
+		return ctrl.Result{}, nil
+	}
+	log := ctrl.LoggerFrom(ctx)
+
+	if status.RotationPhase == "" {
+		rotationDue, requeueAfter := encryptionRotationDue(spec, status)
+		if !rotationDue {
+			return ctrl.Result{RequeueAfter: requeueAfter}, nil
+		}
+	} else {
+		rolledOut, err := r.encryptionConfigRolledOut(ctx, kubeSvc)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !rolledOut {
+			log.Info("Waiting for the api server to roll out before continuing the encryption key rotation", "phase", status.RotationPhase)
+			return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+		}
+	}
+
+	switch status.RotationPhase {
+	case "":
+		now := metav1.Now()
+		log.Info("Adding a new encryption key")
+		status.Keys = append(status.Keys, hyperlitev1.EncryptionKey{
+			Name: kas.NewEncryptionKeyName(now),
+			Type: encryptionType(spec),
+		})
+		status.RotationPhase = hyperlitev1.EncryptionKeyAdded
+	case hyperlitev1.EncryptionKeyAdded:
+		log.Info("Promoting the new encryption key")
+		newKey := status.Keys[len(status.Keys)-1]
+		status.Keys = append([]hyperlitev1.EncryptionKey{newKey}, status.Keys[:len(status.Keys)-1]...)
+		status.RotationPhase = hyperlitev1.EncryptionKeyPromoted
+	case hyperlitev1.EncryptionKeyPromoted:
+		log.Info("Re-encrypting secrets with the new encryption key")
+		if err := r.reencryptSecrets(ctx, kubeSvc); err != nil {
+			return ctrl.Result{}, err
+		}
+		status.RotationPhase = hyperlitev1.EncryptionKeySecretsReencrypted
+	case hyperlitev1.EncryptionKeySecretsReencrypted:
+		log.Info("Removing old encryption keys")
+		now := metav1.Now()
+		status.Keys = status.Keys[:1]
+		status.RotationPhase = ""
+		status.LastRotationTime = &now
+	}
+	if err := r.Status().Update(ctx, kubeSvc); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update encryption key rotation status: %w", err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// encryptionConfigRolledOut returns true once every API server pod runs with
+// the current encryption configuration. The deployment and the configuration
+// are read from the API server rather than the cache, which may not have seen
+// an update made earlier in the same reconcile yet.
+func (r *KubernetesServiceReconciler) encryptionConfigRolledOut(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService) (bool, error) {
+	namespace, instance := ks.ControlPlaneNamespace(kubeSvc), ks.InstanceName(kubeSvc)
+	encryptionConfig := kas.EncryptionConfigSecret(namespace, instance)
+	if err := r.Reader.Get(ctx, client.ObjectKeyFromObject(encryptionConfig), encryptionConfig); err != nil {
+		return false, fmt.Errorf("cannot get encryption config: %w", err)
+	}
+	deployment := kas.Deployment(namespace, instance)
+	if err := r.Reader.Get(ctx, client.ObjectKeyFromObject(deployment), deployment); err != nil {
+		return false, fmt.Errorf("cannot get api server deployment: %w", err)
+	}
+	return deployment.Status.ObservedGeneration == deployment.Generation &&
+		deployment.Spec.Template.Annotations[kas.EncryptionConfigHashAnnotation] == encryptionConfigHash(encryptionConfig) &&
+		ks.DeploymentRolledOut(deployment), nil
+}
+
+// reencryptSecrets rewrites every secret of the hosted cluster so that it is
+// stored with the current write key.
+func (r *KubernetesServiceReconciler) reencryptSecrets(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService) error {
+	hostedClient, err := r.hostedClient(ctx, kubeSvc)
+	if err != nil {
+		return err
+	}
+	opts := metav1.ListOptions{Limit: reencryptPageSize}
+	for {
+		secrets, err := hostedClient.CoreV1().Secrets(metav1.NamespaceAll).List(ctx, opts)
+		if err != nil {
+			return fmt.Errorf("failed to list hosted cluster secrets: %w", err)
+		}
+		for i := range secrets.Items {
+			secret := &secrets.Items[i]
+			// Secrets that changed or went away since they were listed have
+			// already been written with the current key
+			if _, err := hostedClient.CoreV1().Secrets(secret.Namespace).Update(ctx, secret, metav1.UpdateOptions{}); err != nil && !apierrors.IsConflict(err) && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to re-encrypt secret %s/%s: %w", secret.Namespace, secret.Name, err)
+			}
+		}
+		if secrets.Continue == "" {
+			return nil
+		}
+		opts.Continue = secrets.Continue
+	}
+}
+
+// encryptionRotationDue returns whether the encryption key must be rotated
+// because the encryption type changed or the rotation interval elapsed, and
+// otherwise how long until the next scheduled rotation.
+func encryptionRotationDue(spec *hyperlitev1.SecretEncryptionSpec, status *hyperlitev1.SecretEncryptionStatus) (bool, time.Duration) {
+	if status.Keys[0].Type != encryptionType(spec) {
+		return true, 0
+	}
+	if spec.RotationInterval == nil || status.LastRotationTime == nil {
+		return false, 0
+	}
+	untilRotation := time.Until(status.LastRotationTime.Add(spec.RotationInterval.Duration))
+	if untilRotation <= 0 {
+		return true, 0
+	}
+	return false, untilRotation
+}
+
+// encryptionConfigHash returns the hash of an encryption configuration secret,
+// or an empty string when encryption is not enabled.
+func encryptionConfigHash(encryptionConfig *corev1.Secret) string {
+	if encryptionConfig == nil {
+		return ""
+	}
+	return kas.ConfigHash(encryptionConfig)
+}
+
+// kmsPlugin returns the KMS plugin settings when any configured encryption key
+// uses KMS, and nil otherwise.
+func kmsPlugin(kubeSvc *hyperlitev1.KubernetesService) *hyperlitev1.KMSSpec {
+	if kubeSvc.Spec.Security.SecretEncryption == nil || kubeSvc.Status.SecretEncryption == nil {
+		return nil
+	}
+	for _, key := range kubeSvc.Status.SecretEncryption.Keys {
+		if key.Type == hyperlitev1.KMSEncryption {
+			return kubeSvc.Spec.Security.SecretEncryption.KMS
+		}
+	}
+	return nil
+}
+
+func encryptionType(spec *hyperlitev1.SecretEncryptionSpec) hyperlitev1.SecretEncryptionType {
+	if spec.Type == "" {
+		return hyperlitev1.AESCBCEncryption
+	}
+	return spec.Type
+}
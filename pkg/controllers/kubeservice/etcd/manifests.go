@@ -3,79 +3,108 @@ package etcd
 import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/ks"
 	etcdv1 "github.com/openshift-hive/hypershiftlite/thirdparty/etcd/v1beta2"
 )
 
-func ClientSecret(ns string) *corev1.Secret {
+func ClientSecret(ns, instance string) *corev1.Secret {
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "etcd-client-tls",
+			Name:      ks.Name(instance, "etcd-client-tls"),
 			Namespace: ns,
 		},
 	}
 }
 
-func ServerSecret(ns string) *corev1.Secret {
+func ServerSecret(ns, instance string) *corev1.Secret {
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "etcd-server-tls",
+			Name:      ks.Name(instance, "etcd-server-tls"),
 			Namespace: ns,
 		},
 	}
 }
 
-func PeerSecret(ns string) *corev1.Secret {
+func PeerSecret(ns, instance string) *corev1.Secret {
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "etcd-peer-tls",
+			Name:      ks.Name(instance, "etcd-peer-tls"),
 			Namespace: ns,
 		},
 	}
 }
 
-func OperatorServiceAccount(ns string) *corev1.ServiceAccount {
+func OperatorServiceAccount(ns, instance string) *corev1.ServiceAccount {
 	return &corev1.ServiceAccount{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "etcd-operator",
+			Name:      ks.Name(instance, "etcd-operator"),
 			Namespace: ns,
 		},
 	}
 }
 
-func OperatorRole(ns string) *rbacv1.Role {
+func OperatorRole(ns, instance string) *rbacv1.Role {
 	return &rbacv1.Role{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "etcd-operator",
+			Name:      ks.Name(instance, "etcd-operator"),
 			Namespace: ns,
 		},
 	}
 }
 
-func OperatorRoleBinding(ns string) *rbacv1.RoleBinding {
+func OperatorRoleBinding(ns, instance string) *rbacv1.RoleBinding {
 	return &rbacv1.RoleBinding{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "etcd-operator",
+			Name:      ks.Name(instance, "etcd-operator"),
 			Namespace: ns,
 		},
 	}
 }
 
-func OperatorDeployment(ns string) *appsv1.Deployment {
+func OperatorDeployment(ns, instance string) *appsv1.Deployment {
 	return &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "etcd-operator",
+			Name:      ks.Name(instance, "etcd-operator"),
 			Namespace: ns,
 		},
 	}
 }
 
-func Cluster(ns string) *etcdv1.EtcdCluster {
+func Cluster(ns, instance string) *etcdv1.EtcdCluster {
 	return &etcdv1.EtcdCluster{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "etcd",
+			Name:      ks.Name(instance, "etcd"),
+			Namespace: ns,
+		},
+	}
+}
+
+func PodDisruptionBudget(ns, instance string) *policyv1beta1.PodDisruptionBudget {
+	return &policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ks.Name(instance, "etcd"),
+			Namespace: ns,
+		},
+	}
+}
+
+func Backup(ns, instance, snapshot string) *etcdv1.EtcdBackup {
+	return &etcdv1.EtcdBackup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ks.Name(instance, "etcd-snapshot-"+snapshot),
+			Namespace: ns,
+		},
+	}
+}
+
+func Restore(ns, instance, snapshot string) *etcdv1.EtcdRestore {
+	return &etcdv1.EtcdRestore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ks.Name(instance, "etcd-restore-"+snapshot),
 			Namespace: ns,
 		},
 	}
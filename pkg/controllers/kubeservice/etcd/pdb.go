@@ -0,0 +1,21 @@
+package etcd
+
+import (
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// ReconcilePodDisruptionBudget ensures a quorum of etcd members stays up
+// during voluntary disruptions.
+func ReconcilePodDisruptionBudget(pdb *policyv1beta1.PodDisruptionBudget, instance string, size int) error {
+	minAvailable := intstr.FromInt(size/2 + 1)
+	pdb.Spec.Selector = &metav1.LabelSelector{
+		MatchLabels: map[string]string{
+			etcdClusterLabel: Cluster(pdb.Namespace, instance).Name,
+		},
+	}
+	pdb.Spec.MinAvailable = &minAvailable
+	pdb.Spec.MaxUnavailable = nil
+	return nil
+}
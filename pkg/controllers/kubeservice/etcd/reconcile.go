@@ -8,10 +8,13 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
 	"github.com/openshift-hive/hypershiftlite/pkg/certs"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/ks"
 	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/pki"
 	etcdv1 "github.com/openshift-hive/hypershiftlite/thirdparty/etcd/v1beta2"
 )
@@ -87,12 +90,12 @@ func ReconcileOperatorRole(role *rbacv1.Role) error {
 	return nil
 }
 
-func ReconcileOperatorRoleBinding(roleBinding *rbacv1.RoleBinding) error {
-	serviceAccount := OperatorServiceAccount(roleBinding.Namespace)
+func ReconcileOperatorRoleBinding(roleBinding *rbacv1.RoleBinding, instance string) error {
+	serviceAccount := OperatorServiceAccount(roleBinding.Namespace, instance)
 	roleBinding.RoleRef = rbacv1.RoleRef{
 		APIGroup: rbacv1.SchemeGroupVersion.Group,
 		Kind:     "Role",
-		Name:     "etcd-operator",
+		Name:     OperatorRole(roleBinding.Namespace, instance).Name,
 	}
 	roleBinding.Subjects = []rbacv1.Subject{
 		{
@@ -105,26 +108,71 @@ func ReconcileOperatorRoleBinding(roleBinding *rbacv1.RoleBinding) error {
 	return nil
 }
 
-var etcdOperatorDeploymentLabels = map[string]string{
-	"name": "etcd-operator",
+func etcdOperatorDeploymentLabels(instance string) map[string]string {
+	return map[string]string{
+		"name": ks.Name(instance, "etcd-operator"),
+	}
 }
 
-func ReconcileOperatorDeployment(deployment *appsv1.Deployment, operatorImage string) error {
-	serviceAccount := OperatorServiceAccount(deployment.Namespace)
+const (
+	etcdOperatorContainer        = "etcd-operator"
+	etcdBackupOperatorContainer  = "etcd-backup-operator"
+	etcdRestoreOperatorContainer = "etcd-restore-operator"
+)
+
+var (
+	etcdOperatorDefaultResources = corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("10m"),
+			corev1.ResourceMemory: resource.MustParse("50Mi"),
+		},
+	}
+	etcdDefaultResources = corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("300m"),
+			corev1.ResourceMemory: resource.MustParse("600Mi"),
+		},
+	}
+)
+
+// ReconcileOperatorDeployment runs the etcd operator along with the etcd
+// backup operator, which takes the snapshots requested by EtcdBackups, and the
+// etcd restore operator, which recreates etcd clusters from snapshots as
+// requested by EtcdRestores.
+func ReconcileOperatorDeployment(deployment *appsv1.Deployment, instance, operatorImage string, component *hyperlitev1.ComponentSpec) error {
+	serviceAccount := OperatorServiceAccount(deployment.Namespace, instance)
+	env := []corev1.EnvVar{
+		{
+			Name: "MY_POD_NAMESPACE",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: "metadata.namespace",
+				},
+			},
+		},
+		{
+			Name: "MY_POD_NAME",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: "metadata.name",
+				},
+			},
+		},
+	}
 	deployment.Spec = appsv1.DeploymentSpec{
 		Replicas: pointer.Int32Ptr(1),
 		Selector: &metav1.LabelSelector{
-			MatchLabels: etcdOperatorDeploymentLabels,
+			MatchLabels: etcdOperatorDeploymentLabels(instance),
 		},
 		Template: corev1.PodTemplateSpec{
 			ObjectMeta: metav1.ObjectMeta{
-				Labels: etcdOperatorDeploymentLabels,
+				Labels: etcdOperatorDeploymentLabels(instance),
 			},
 			Spec: corev1.PodSpec{
 				ServiceAccountName: serviceAccount.Name,
 				Containers: []corev1.Container{
 					{
-						Name:  "etcd-operator",
+						Name:  etcdOperatorContainer,
 						Image: operatorImage,
 						Command: []string{
 							"etcd-operator",
@@ -132,36 +180,44 @@ func ReconcileOperatorDeployment(deployment *appsv1.Deployment, operatorImage st
 						Args: []string{
 							"-create-crd=false",
 						},
-						Env: []corev1.EnvVar{
-							{
-								Name: "MY_POD_NAMESPACE",
-								ValueFrom: &corev1.EnvVarSource{
-									FieldRef: &corev1.ObjectFieldSelector{
-										FieldPath: "metadata.namespace",
-									},
-								},
-							},
-							{
-								Name: "MY_POD_NAME",
-								ValueFrom: &corev1.EnvVarSource{
-									FieldRef: &corev1.ObjectFieldSelector{
-										FieldPath: "metadata.name",
-									},
-								},
-							},
+						Env: env,
+					},
+					{
+						Name:  etcdBackupOperatorContainer,
+						Image: operatorImage,
+						Command: []string{
+							"etcd-backup-operator",
+						},
+						Args: []string{
+							"-create-crd=false",
 						},
+						Env:       env,
+						Resources: etcdOperatorDefaultResources,
+					},
+					{
+						Name:  etcdRestoreOperatorContainer,
+						Image: operatorImage,
+						Command: []string{
+							"etcd-restore-operator",
+						},
+						Args: []string{
+							"-create-crd=false",
+						},
+						Env:       env,
+						Resources: etcdOperatorDefaultResources,
 					},
 				},
 			},
 		},
 	}
+	ks.ApplyComponentSpec(&deployment.Spec.Template.Spec, etcdOperatorContainer, component, etcdOperatorDefaultResources)
 	return nil
 }
 
-func ReconcileCluster(cluster *etcdv1.EtcdCluster, size int, version string) error {
-	peerSecret := PeerSecret(cluster.Namespace)
-	serverSecret := ServerSecret(cluster.Namespace)
-	clientSecret := ClientSecret(cluster.Namespace)
+func ReconcileCluster(cluster *etcdv1.EtcdCluster, instance string, size int, version string, component *hyperlitev1.ComponentSpec) error {
+	peerSecret := PeerSecret(cluster.Namespace, instance)
+	serverSecret := ServerSecret(cluster.Namespace, instance)
+	clientSecret := ClientSecret(cluster.Namespace, instance)
 
 	cluster.Spec = etcdv1.ClusterSpec{
 		Size:    size,
@@ -176,6 +232,22 @@ func ReconcileCluster(cluster *etcdv1.EtcdCluster, size int, version string) err
 			},
 		},
 	}
+	podPolicy := &etcdv1.PodPolicy{
+		Resources: etcdDefaultResources,
+	}
+	if component != nil {
+		if component.Resources != nil {
+			podPolicy.Resources = *component.Resources
+		}
+		podPolicy.NodeSelector = component.NodeSelector
+		podPolicy.Tolerations = component.Tolerations
+	}
+	if size > 1 {
+		podPolicy.Affinity = ks.PodAntiAffinity(map[string]string{
+			etcdClusterLabel: cluster.Name,
+		})
+	}
+	cluster.Spec.Pod = podPolicy
 	return nil
 }
 
@@ -207,20 +279,21 @@ func ReconcileClientSecret(secret, ca *corev1.Secret) error {
 	return nil
 }
 
-func ReconcileServerSecret(secret, ca *corev1.Secret) error {
+func ReconcileServerSecret(secret, ca *corev1.Secret, instance string) error {
 	if !pki.ValidCA(ca) {
 		return fmt.Errorf("Invalid CA signer secret %s", ca.Name)
 	}
 	secret.Type = corev1.SecretTypeOpaque
 	expectedKeys := []string{ServerCrtKey, ServerKeyKey, ServerCAKey}
 	if !pki.SignedSecretUpToDate(secret, ca, expectedKeys) {
+		clusterName := Cluster(secret.Namespace, instance).Name
 		dnsNames := []string{
-			fmt.Sprintf("*.etcd.%s.svc", secret.Namespace),
-			fmt.Sprintf("etcd-client.%s.svc", secret.Namespace),
-			fmt.Sprintf("*.etcd.%s.svc.cluster.local", secret.Namespace),
-			fmt.Sprintf("etcd-client.%s.svc.cluster.local", secret.Namespace),
-			"etcd",
-			"etcd-client",
+			fmt.Sprintf("*.%s.%s.svc", clusterName, secret.Namespace),
+			fmt.Sprintf("%s-client.%s.svc", clusterName, secret.Namespace),
+			fmt.Sprintf("*.%s.%s.svc.cluster.local", clusterName, secret.Namespace),
+			fmt.Sprintf("%s-client.%s.svc.cluster.local", clusterName, secret.Namespace),
+			clusterName,
+			fmt.Sprintf("%s-client", clusterName),
 			"localhost",
 		}
 		cfg := &certs.CertCfg{
@@ -245,16 +318,17 @@ func ReconcileServerSecret(secret, ca *corev1.Secret) error {
 	return nil
 }
 
-func ReconcilePeerSecret(secret, ca *corev1.Secret) error {
+func ReconcilePeerSecret(secret, ca *corev1.Secret, instance string) error {
 	if !pki.ValidCA(ca) {
 		return fmt.Errorf("Invalid CA signer secret %s", ca.Name)
 	}
 	secret.Type = corev1.SecretTypeOpaque
 	expectedKeys := []string{PeerCrtKey, PeerKeyKey, PeerCAKey}
 	if !pki.SignedSecretUpToDate(secret, ca, expectedKeys) {
+		clusterName := Cluster(secret.Namespace, instance).Name
 		dnsNames := []string{
-			fmt.Sprintf("*.etcd.%s.svc", secret.Namespace),
-			fmt.Sprintf("*.etcd.%s.svc.cluster.local", secret.Namespace),
+			fmt.Sprintf("*.%s.%s.svc", clusterName, secret.Namespace),
+			fmt.Sprintf("*.%s.%s.svc.cluster.local", clusterName, secret.Namespace),
 		}
 		cfg := &certs.CertCfg{
 			Subject:      pkix.Name{CommonName: "etcd-peer", Organization: []string{"kubernetes"}},
@@ -0,0 +1,107 @@
+package etcd
+
+import (
+	"fmt"
+	"path"
+
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
+	etcdv1 "github.com/openshift-hive/hypershiftlite/thirdparty/etcd/v1beta2"
+)
+
+const (
+	// FinalSnapshot is the name of the snapshot taken when a KubernetesService
+	// with the Snapshot deletion policy is deleted.
+	FinalSnapshot = "final"
+
+	// HibernationSnapshot is the name of the snapshot taken when a
+	// KubernetesService is hibernated, which it is restored from when it
+	// resumes.
+	HibernationSnapshot = "hibernation"
+
+	// CloneSnapshot is the name of the snapshot a KubernetesService created
+	// from another one is restored from.
+	CloneSnapshot = "clone"
+
+	// UpgradeSnapshot is the name of the snapshot taken before a
+	// KubernetesService is upgraded to another release image.
+	UpgradeSnapshot = "upgrade"
+
+	snapshotTimeoutSeconds = 300
+)
+
+// SnapshotPath returns the location of a snapshot of the etcd cluster of a
+// KubernetesService in the format expected by the etcd backup operator:
+// <bucket>/<prefix>/<namespace>/<name>/<snapshot>.
+func SnapshotPath(storage *hyperlitev1.SnapshotStorageSpec, kubeSvc *hyperlitev1.KubernetesService, snapshot string) string {
+	return path.Join(storage.S3.Bucket, storage.S3.Prefix, kubeSvc.Namespace, kubeSvc.Name, snapshot)
+}
+
+// ReconcileBackup configures a one-shot backup of the etcd cluster of an
+// instance to the given path of the snapshot storage. The credentials secret
+// must be in the namespace of the backup.
+func ReconcileBackup(backup *etcdv1.EtcdBackup, instance string, storage *hyperlitev1.SnapshotStorageSpec, snapshotPath string) error {
+	if storage == nil {
+		return fmt.Errorf("snapshot storage is not configured")
+	}
+	cluster := Cluster(backup.Namespace, instance)
+	backup.Spec = etcdv1.BackupSpec{
+		EtcdEndpoints: []string{
+			fmt.Sprintf("https://%s-client.%s.svc:2379", cluster.Name, cluster.Namespace),
+		},
+		StorageType: etcdv1.BackupStorageTypeS3,
+		BackupPolicy: &etcdv1.BackupPolicy{
+			TimeoutInSecond: snapshotTimeoutSeconds,
+		},
+		BackupSource: etcdv1.BackupSource{
+			S3: &etcdv1.S3BackupSource{
+				Path:           snapshotPath,
+				AWSSecret:      storage.S3.Credentials.Name,
+				Endpoint:       storage.S3.Endpoint,
+				ForcePathStyle: storage.S3.ForcePathStyle,
+			},
+		},
+		ClientTLSSecret: ClientSecret(backup.Namespace, instance).Name,
+	}
+	return nil
+}
+
+// BackupFailed returns the reason a backup failed, or an empty string if it
+// succeeded or is still in progress.
+func BackupFailed(backup *etcdv1.EtcdBackup) string {
+	if backup.Status.Succeeded {
+		return ""
+	}
+	return backup.Status.Reason
+}
+
+// ReconcileRestore configures the restore of the etcd cluster of an instance
+// from the snapshot at the given path of the snapshot storage. The etcd
+// restore operator replaces the existing cluster with one seeded from the
+// snapshot.
+func ReconcileRestore(restore *etcdv1.EtcdRestore, instance string, storage *hyperlitev1.SnapshotStorageSpec, snapshotPath string) error {
+	if storage == nil {
+		return fmt.Errorf("snapshot storage is not configured")
+	}
+	restore.Spec = etcdv1.RestoreSpec{
+		BackupStorageType: etcdv1.BackupStorageTypeS3,
+		RestoreSource: etcdv1.RestoreSource{
+			S3: &etcdv1.S3RestoreSource{
+				Path:           snapshotPath,
+				AWSSecret:      storage.S3.Credentials.Name,
+				Endpoint:       storage.S3.Endpoint,
+				ForcePathStyle: storage.S3.ForcePathStyle,
+			},
+		},
+		EtcdCluster: etcdv1.EtcdClusterRef{
+			Name: Cluster(restore.Namespace, instance).Name,
+		},
+	}
+	return nil
+}
+
+// MemberLabels returns the labels of the pods of an etcd cluster.
+func MemberLabels(cluster *etcdv1.EtcdCluster) map[string]string {
+	return map[string]string{
+		etcdClusterLabel: cluster.Name,
+	}
+}
@@ -9,7 +9,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1alpha1"
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
 	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/ks"
 	etcdv1 "github.com/openshift-hive/hypershiftlite/thirdparty/etcd/v1beta2"
 )
@@ -28,7 +28,10 @@ func etcdClusterConditionByType(conditions []etcdv1.ClusterCondition, t etcdv1.C
 	return nil
 }
 
-func ReconcileEtcdClusterStatus(ctx context.Context, c client.Client, kubeSvc *hyperlitev1.KubernetesService, cluster *etcdv1.EtcdCluster) error {
+// ReconcileEtcdClusterStatus reports the availability of the etcd cluster.
+// A cluster that failed is deleted so that it is recreated, unless
+// recreateFailed is false.
+func ReconcileEtcdClusterStatus(ctx context.Context, c client.Client, kubeSvc *hyperlitev1.KubernetesService, cluster *etcdv1.EtcdCluster, recreateFailed bool) error {
 	log := ctrl.LoggerFrom(ctx)
 	if cluster == nil {
 		// etcd cluster doesn't yet exist, nothing to do yet
@@ -63,7 +66,7 @@ func ReconcileEtcdClusterStatus(ctx context.Context, c client.Client, kubeSvc *h
 	if err := c.Status().Update(ctx, kubeSvc); err != nil {
 		return err
 	}
-	if shouldDelete {
+	if shouldDelete && recreateFailed {
 		err := c.Delete(ctx, cluster)
 		if err != nil {
 			return err
@@ -73,10 +76,23 @@ func ReconcileEtcdClusterStatus(ctx context.Context, c client.Client, kubeSvc *h
 	return nil
 }
 
+// ClusterDegraded returns true if the etcd cluster is available but has fewer
+// ready members than its desired size.
+func ClusterDegraded(cluster *etcdv1.EtcdCluster) bool {
+	if cluster == nil {
+		return false
+	}
+	availableCondition := etcdClusterConditionByType(cluster.Status.Conditions, etcdv1.ClusterConditionAvailable)
+	if availableCondition == nil || availableCondition.Status != corev1.ConditionTrue {
+		return false
+	}
+	return len(cluster.Status.Members.Ready) < cluster.Spec.Size
+}
+
 func etcdClusterHasTerminatedPods(ctx context.Context, c client.Client, cluster *etcdv1.EtcdCluster) (bool, error) {
 	// If only one member ready and waiting for another to come up, check pod status
 	etcdPods := &corev1.PodList{}
-	err := c.List(ctx, etcdPods, client.MatchingLabels{etcdClusterLabel: cluster.Name})
+	err := c.List(ctx, etcdPods, client.InNamespace(cluster.Namespace), client.MatchingLabels{etcdClusterLabel: cluster.Name})
 	if err != nil {
 		return false, fmt.Errorf("cannot list etcd cluster pods: %w", err)
 	}
@@ -0,0 +1,87 @@
+package kubeservice
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/ks"
+)
+
+// reconcileExpiration reports the remaining lifetime of a KubernetesService in
+// its status, emits warning events as it approaches its expiry, and deletes it
+// once it has expired unless reconciliation is paused. It returns whether it
+// was deleted and, while it has not expired, how long until the remaining
+// lifetime in the status changes.
+func (r *KubernetesServiceReconciler) reconcileExpiration(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService, paused bool) (bool, time.Duration, error) {
+	expiresAt, expires, err := ks.ExpiresAt(kubeSvc)
+	if err != nil {
+		// Reported in the ValidConfiguration condition
+		return false, 0, nil
+	}
+	if !expires {
+		kubeSvc.Status.Expiration = nil
+		return false, 0, nil
+	}
+	remaining := time.Until(expiresAt)
+
+	expiration := kubeSvc.Status.Expiration
+	if expiration == nil {
+		expiration = &hyperlitev1.ExpirationStatus{}
+		kubeSvc.Status.Expiration = expiration
+	}
+	expiration.ExpiresAt = metav1.NewTime(expiresAt)
+	expiration.Remaining = formatRemaining(remaining)
+	if expiration.LastWarning != nil && remaining > expiration.LastWarning.Duration {
+		// The lifetime was extended
+		expiration.LastWarning = nil
+	}
+
+	if remaining <= 0 {
+		if paused {
+			return false, 0, nil
+		}
+		ctrl.LoggerFrom(ctx).Info("KubernetesService expired, deleting it", "expiresAt", expiresAt)
+		r.recorder.Eventf(kubeSvc, corev1.EventTypeWarning, "Expired", "KubernetesService expired at %s, deleting it", expiresAt.UTC().Format(time.RFC3339))
+		if err := r.Delete(ctx, kubeSvc); err != nil && !apierrors.IsNotFound(err) {
+			return false, 0, fmt.Errorf("failed to delete expired kubernetes service: %w", err)
+		}
+		return true, 0, nil
+	}
+
+	// Warn once for the shortest warning the remaining lifetime is within
+	var warning time.Duration
+	for _, w := range ks.ExpiryWarnings(kubeSvc) {
+		if remaining <= w {
+			warning = w
+		}
+	}
+	if warning > 0 && (expiration.LastWarning == nil || warning < expiration.LastWarning.Duration) {
+		r.recorder.Eventf(kubeSvc, corev1.EventTypeWarning, "ExpiringSoon", "KubernetesService expires in %s at %s. Raise the %s annotation to extend its lifetime", expiration.Remaining, expiresAt.UTC().Format(time.RFC3339), ks.LifetimeExtensionAnnotation)
+		expiration.LastWarning = &metav1.Duration{Duration: warning}
+	}
+
+	if remaining < time.Minute {
+		return false, remaining, nil
+	}
+	return false, remaining - remaining.Truncate(time.Minute) + time.Second, nil
+}
+
+// formatRemaining formats the remaining lifetime of a KubernetesService to the
+// minute, for example "1h30m".
+func formatRemaining(remaining time.Duration) string {
+	switch {
+	case remaining <= 0:
+		return "0m"
+	case remaining < time.Minute:
+		return "<1m"
+	}
+	return strings.TrimSuffix(remaining.Truncate(time.Minute).String(), "0s")
+}
@@ -0,0 +1,174 @@
+package kubeservice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/etcd"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/ks"
+	etcdv1 "github.com/openshift-hive/hypershiftlite/thirdparty/etcd/v1beta2"
+)
+
+// reconcilePowerState hibernates or resumes the control plane of a
+// KubernetesService according to its power state. It returns true when the
+// rest of the control plane must not be reconciled, along with the result to
+// return.
+func (r *KubernetesServiceReconciler) reconcilePowerState(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService) (bool, ctrl.Result, error) {
+	if ks.PowerState(kubeSvc) == hyperlitev1.HibernatingPowerState {
+		result, err := r.reconcileHibernation(ctx, kubeSvc)
+		return true, result, err
+	}
+	if kubeSvc.Status.Hibernation != nil {
+		restored, err := r.reconcileResume(ctx, kubeSvc)
+		if err != nil {
+			return true, ctrl.Result{}, err
+		}
+		if !restored {
+			return true, ctrl.Result{RequeueAfter: restorePollInterval}, nil
+		}
+	}
+
+	// The control plane has resumed once it is available again
+	resuming := ks.GetConditionByType(kubeSvc.Status.Conditions, hyperlitev1.Resuming)
+	available := ks.GetConditionByType(kubeSvc.Status.Conditions, hyperlitev1.Available)
+	if resuming != nil && resuming.Status == corev1.ConditionTrue && available != nil && available.Status == corev1.ConditionTrue {
+		ks.SetConditionByType(&kubeSvc.Status.Conditions, hyperlitev1.Resuming, corev1.ConditionFalse, "Resumed", "The control plane has resumed")
+		if err := r.Status().Update(ctx, kubeSvc); err != nil {
+			return true, ctrl.Result{}, fmt.Errorf("failed to update kubernetes service status: %w", err)
+		}
+	}
+	return false, ctrl.Result{}, nil
+}
+
+// reconcileHibernation stops the control plane of a KubernetesService, one
+// step per reconcile. The controller manager and API server are stopped first,
+// then etcd is saved to the snapshot storage and its members are removed, and
+// finally the etcd operator is stopped. The etcd cluster is kept, paused, so
+// that it can be restored in place, along with all secrets.
+func (r *KubernetesServiceReconciler) reconcileHibernation(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	namespace, instance := ks.ControlPlaneNamespace(kubeSvc), ks.InstanceName(kubeSvc)
+	etcdCluster := etcd.Cluster(namespace, instance)
+	etcdExists := true
+	if err := r.Get(ctx, client.ObjectKeyFromObject(etcdCluster), etcdCluster); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("cannot get etcd cluster: %w", err)
+		}
+		etcdExists = false
+	}
+
+	if kubeSvc.Status.Hibernation == nil {
+		log.Info("Hibernating control plane")
+		kubeSvc.Status.Hibernation = &hyperlitev1.HibernationStatus{}
+		if etcdExists {
+			kubeSvc.Status.Hibernation.Snapshot = etcd.SnapshotPath(kubeSvc.Spec.Lifecycle.SnapshotStorage, kubeSvc, fmt.Sprintf("%s-%s", etcd.HibernationSnapshot, time.Now().UTC().Format(snapshotTimeFormat)))
+		}
+		ks.SetConditionByType(&kubeSvc.Status.Conditions, hyperlitev1.Resuming, corev1.ConditionFalse, "Hibernating", "The control plane is hibernating")
+		ks.SetConditionByType(&kubeSvc.Status.Conditions, hyperlitev1.Hibernating, corev1.ConditionTrue, "Hibernating", "The control plane is hibernating")
+		if err := r.Status().Update(ctx, kubeSvc); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update kubernetes service status: %w", err)
+		}
+	}
+	hibernation := kubeSvc.Status.Hibernation
+
+	// A restore of an interrupted resume must not recreate etcd
+	if err := r.deleteIfExists(ctx, etcd.Restore(namespace, instance, etcd.HibernationSnapshot)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to delete etcd restore: %w", err)
+	}
+
+	if stopped, err := r.stopAPIServer(ctx, kubeSvc, hyperlitev1.Hibernating); err != nil || !stopped {
+		return ctrl.Result{RequeueAfter: teardownPollInterval}, err
+	}
+
+	if hibernation.Snapshot != "" && hibernation.SnapshotTime == nil {
+		saved, err := r.reconcileSnapshot(ctx, kubeSvc, etcd.HibernationSnapshot, hibernation.Snapshot, hyperlitev1.Hibernating)
+		if err != nil || !saved {
+			return ctrl.Result{RequeueAfter: teardownPollInterval}, err
+		}
+		r.recorder.Eventf(kubeSvc, corev1.EventTypeNormal, "SnapshotSaved", "Saved etcd snapshot to %s", hibernation.Snapshot)
+		now := metav1.Now()
+		hibernation.SnapshotTime = &now
+		if err := r.Status().Update(ctx, kubeSvc); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update kubernetes service status: %w", err)
+		}
+		if err := r.deleteIfExists(ctx, etcd.Backup(namespace, instance, etcd.HibernationSnapshot)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to delete etcd backup: %w", err)
+		}
+	}
+
+	if etcdExists {
+		if stopped, err := r.stopEtcdMembers(ctx, etcdCluster); err != nil || !stopped {
+			if err == nil {
+				err = r.setProgressCondition(ctx, kubeSvc, hyperlitev1.Hibernating, "StoppingEtcd", "Waiting for the etcd members to stop")
+			}
+			return ctrl.Result{RequeueAfter: teardownPollInterval}, err
+		}
+	}
+	if stopped, err := r.scaleDownDeployment(ctx, etcd.OperatorDeployment(namespace, instance)); err != nil || !stopped {
+		if err == nil {
+			err = r.setProgressCondition(ctx, kubeSvc, hyperlitev1.Hibernating, "StoppingEtcdOperator", "Waiting for the etcd operator to stop")
+		}
+		return ctrl.Result{RequeueAfter: teardownPollInterval}, err
+	}
+
+	return ctrl.Result{}, r.setProgressCondition(ctx, kubeSvc, hyperlitev1.Hibernating, "Hibernated", "The control plane is hibernated")
+}
+
+// stopEtcdMembers pauses an etcd cluster so that the etcd operator does not
+// replace its members, and deletes them. It returns true once they are gone.
+func (r *KubernetesServiceReconciler) stopEtcdMembers(ctx context.Context, cluster *etcdv1.EtcdCluster) (bool, error) {
+	if !cluster.Spec.Paused {
+		cluster.Spec.Paused = true
+		if err := r.Update(ctx, cluster); err != nil {
+			return false, fmt.Errorf("failed to pause etcd cluster: %w", err)
+		}
+	}
+	members := &corev1.PodList{}
+	if err := r.List(ctx, members, client.InNamespace(cluster.Namespace), client.MatchingLabels(etcd.MemberLabels(cluster))); err != nil {
+		return false, fmt.Errorf("failed to list etcd members: %w", err)
+	}
+	for i := range members.Items {
+		if !members.Items[i].DeletionTimestamp.IsZero() {
+			continue
+		}
+		if err := r.Delete(ctx, &members.Items[i]); err != nil && !apierrors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to delete etcd member %s: %w", members.Items[i].Name, err)
+		}
+	}
+	return len(members.Items) == 0, nil
+}
+
+// reconcileResume restores etcd of a hibernated control plane from its
+// snapshot and returns true once done, so that the rest of the control plane
+// is started again by the regular reconciliation.
+func (r *KubernetesServiceReconciler) reconcileResume(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService) (bool, error) {
+	hibernation := kubeSvc.Status.Hibernation
+	if condition := ks.GetConditionByType(kubeSvc.Status.Conditions, hyperlitev1.Hibernating); condition != nil && condition.Status == corev1.ConditionTrue {
+		ctrl.LoggerFrom(ctx).Info("Resuming control plane")
+		ks.SetConditionByType(&kubeSvc.Status.Conditions, hyperlitev1.Hibernating, corev1.ConditionFalse, "Resuming", "The control plane is resuming")
+	}
+
+	if hibernation.SnapshotTime != nil {
+		if started, err := r.waitForEtcdOperator(ctx, kubeSvc, hyperlitev1.Resuming); err != nil || !started {
+			return false, err
+		}
+		if restored, err := r.reconcileRestore(ctx, kubeSvc, etcd.HibernationSnapshot, hibernation.Snapshot, hyperlitev1.Resuming); err != nil || !restored {
+			return false, err
+		}
+	}
+
+	kubeSvc.Status.Hibernation = nil
+	ks.SetConditionByType(&kubeSvc.Status.Conditions, hyperlitev1.Resuming, corev1.ConditionTrue, "StartingComponents", "Waiting for the control plane to become available")
+	if err := r.Status().Update(ctx, kubeSvc); err != nil {
+		return false, fmt.Errorf("failed to update kubernetes service status: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,163 @@
+package kas
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	configv1 "github.com/openshift/api/config/v1"
+	kcpv1 "github.com/openshift/api/kubecontrolplane/v1"
+
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
+)
+
+// openShiftAdmissionPlugins are the admission plugins enabled in an OpenShift
+// cluster. They are also the plugins explicitly disabled when a profile does
+// not enable them, as some of them are enabled by default.
+var openShiftAdmissionPlugins = []string{
+	"CertificateApproval",
+	"CertificateSigning",
+	"CertificateSubjectRestriction",
+	"DefaultIngressClass",
+	"DefaultStorageClass",
+	"DefaultTolerationSeconds",
+	"LimitRanger",
+	"MutatingAdmissionWebhook",
+	"NamespaceLifecycle",
+	"NodeRestriction",
+	"OwnerReferencesPermissionEnforcement",
+	"PersistentVolumeClaimResize",
+	"PersistentVolumeLabel",
+	"PodNodeSelector",
+	"PodTolerationRestriction",
+	"Priority",
+	"ResourceQuota",
+	"RuntimeClass",
+	"ServiceAccount",
+	"StorageObjectInUseProtection",
+	"TaintNodesByCondition",
+	"ValidatingAdmissionWebhook",
+	"authorization.openshift.io/RestrictSubjectBindings",
+	"authorization.openshift.io/ValidateRoleBindingRestriction",
+	"config.openshift.io/DenyDeleteClusterConfiguration",
+	"config.openshift.io/ValidateAPIServer",
+	"config.openshift.io/ValidateAuthentication",
+	"config.openshift.io/ValidateConsole",
+	"config.openshift.io/ValidateFeatureGate",
+	"config.openshift.io/ValidateImage",
+	"config.openshift.io/ValidateOAuth",
+	"config.openshift.io/ValidateProject",
+	"config.openshift.io/ValidateScheduler",
+	"image.openshift.io/ImagePolicy",
+	"network.openshift.io/ExternalIPRanger",
+	"network.openshift.io/RestrictedEndpointsAdmission",
+	"quota.openshift.io/ClusterResourceQuota",
+	"quota.openshift.io/ValidateClusterResourceQuota",
+	"route.openshift.io/IngressAdmission",
+	"scheduling.openshift.io/OriginPodNodeEnvironment",
+	"security.openshift.io/DefaultSecurityContextConstraints",
+	"security.openshift.io/SCCExecRestrictions",
+	"security.openshift.io/SecurityContextConstraint",
+	"security.openshift.io/ValidateSecurityContextConstraints",
+}
+
+// upstreamAdmissionPlugins are the Kubernetes admission plugins enabled in an
+// OpenShift cluster.
+var upstreamAdmissionPlugins = []string{
+	"CertificateApproval",
+	"CertificateSigning",
+	"CertificateSubjectRestriction",
+	"DefaultIngressClass",
+	"DefaultStorageClass",
+	"DefaultTolerationSeconds",
+	"LimitRanger",
+	"MutatingAdmissionWebhook",
+	"NamespaceLifecycle",
+	"NodeRestriction",
+	"OwnerReferencesPermissionEnforcement",
+	"PersistentVolumeClaimResize",
+	"PersistentVolumeLabel",
+	"PodNodeSelector",
+	"PodTolerationRestriction",
+	"Priority",
+	"ResourceQuota",
+	"RuntimeClass",
+	"ServiceAccount",
+	"StorageObjectInUseProtection",
+	"TaintNodesByCondition",
+	"ValidatingAdmissionWebhook",
+}
+
+// minimalAdmissionPlugins are the admission plugins required for a functional
+// cluster.
+var minimalAdmissionPlugins = []string{
+	"MutatingAdmissionWebhook",
+	"NamespaceLifecycle",
+	"NodeRestriction",
+	"ServiceAccount",
+	"ValidatingAdmissionWebhook",
+}
+
+// AdmissionPlugins returns the admission plugins to enable and to disable for
+// an admission configuration.
+func AdmissionPlugins(admission hyperlitev1.AdmissionSpec) (kcpv1.Arguments, kcpv1.Arguments) {
+	var profilePlugins []string
+	switch admission.Profile {
+	case hyperlitev1.UpstreamAdmissionProfile:
+		profilePlugins = upstreamAdmissionPlugins
+	case hyperlitev1.MinimalAdmissionProfile:
+		profilePlugins = minimalAdmissionPlugins
+	default:
+		profilePlugins = openShiftAdmissionPlugins
+	}
+	disabled := sets.NewString(admission.DisabledPlugins...)
+	enabled := sets.NewString(profilePlugins...).Insert(admission.EnabledPlugins...).Difference(disabled)
+	disabled.Insert(openShiftAdmissionPlugins...)
+	return kcpv1.Arguments(enabled.List()), kcpv1.Arguments(disabled.Difference(enabled).List())
+}
+
+// ValidateAdmission verifies that admission plugins are not both enabled and
+// disabled, and that plugin configurations are set.
+func ValidateAdmission(admission hyperlitev1.AdmissionSpec) error {
+	if both := sets.NewString(admission.EnabledPlugins...).Intersection(sets.NewString(admission.DisabledPlugins...)); both.Len() > 0 {
+		return fmt.Errorf("admission plugins cannot be both enabled and disabled: %s", strings.Join(both.List(), ", "))
+	}
+	for _, cfg := range admission.PluginConfig {
+		if len(cfg.Configuration.Raw) == 0 && cfg.Configuration.Object == nil {
+			return fmt.Errorf("configuration of admission plugin %s is empty", cfg.Name)
+		}
+	}
+	return nil
+}
+
+// admissionPluginConfig returns the configuration of the enabled admission
+// plugins. Configuration provided in the admission spec replaces the
+// configuration generated by the operator.
+func admissionPluginConfig(params *ConfigParams, enabled kcpv1.Arguments) map[string]configv1.AdmissionPluginConfig {
+	enabledPlugins := sets.NewString(enabled...)
+	pluginConfig := map[string]configv1.AdmissionPluginConfig{}
+	if enabledPlugins.Has("network.openshift.io/ExternalIPRanger") {
+		pluginConfig["network.openshift.io/ExternalIPRanger"] = configv1.AdmissionPluginConfig{
+			Location: "",
+			Configuration: runtime.RawExtension{
+				Object: externalIPRangerConfig(),
+			},
+		}
+	}
+	if enabledPlugins.Has("network.openshift.io/RestrictedEndpointsAdmission") {
+		pluginConfig["network.openshift.io/RestrictedEndpointsAdmission"] = configv1.AdmissionPluginConfig{
+			Location: "",
+			Configuration: runtime.RawExtension{
+				Object: restrictedEndpointsAdmission(params.ServiceCIDR, params.PodCIDR),
+			},
+		}
+	}
+	for _, cfg := range params.Admission.PluginConfig {
+		pluginConfig[cfg.Name] = configv1.AdmissionPluginConfig{
+			Configuration: cfg.Configuration,
+		}
+	}
+	return pluginConfig
+}
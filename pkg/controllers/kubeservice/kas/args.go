@@ -0,0 +1,96 @@
+package kas
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	kcpv1 "github.com/openshift/api/kubecontrolplane/v1"
+
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/ks"
+)
+
+// deniedExtraArgs are API server arguments managed by the operator that
+// cannot be overridden with extra arguments.
+var deniedExtraArgs = sets.NewString(
+	"admission-control-config-file",
+	"advertise-address",
+	"audit-policy-file",
+	"authentication-token-webhook-config-file",
+	"authorization-mode",
+	"authorization-webhook-config-file",
+	"bind-address",
+	"client-ca-file",
+	"disable-admission-plugins",
+	"enable-admission-plugins",
+	"encryption-provider-config",
+	"etcd-cafile",
+	"etcd-certfile",
+	"etcd-keyfile",
+	"etcd-prefix",
+	"etcd-servers",
+	"feature-gates",
+	"insecure-port",
+	"kubelet-certificate-authority",
+	"kubelet-client-certificate",
+	"kubelet-client-key",
+	"oidc-ca-file",
+	"oidc-client-id",
+	"oidc-groups-claim",
+	"oidc-groups-prefix",
+	"oidc-issuer-url",
+	"oidc-username-claim",
+	"oidc-username-prefix",
+	"proxy-client-cert-file",
+	"proxy-client-key-file",
+	"requestheader-client-ca-file",
+	"secure-port",
+	"service-account-key-file",
+	"service-account-signing-key-file",
+	"service-cluster-ip-range",
+	"storage-backend",
+	"tls-cert-file",
+	"tls-private-key-file",
+	"tls-sni-cert-key",
+)
+
+// ValidateExtraArgs verifies that extra API server arguments do not override
+// arguments managed by the operator.
+func ValidateExtraArgs(extraArgs map[string]string) error {
+	return ks.ValidateExtraArgs(extraArgs, deniedExtraArgs)
+}
+
+// ValidateFeatureGates verifies that feature gate names are well formed.
+func ValidateFeatureGates(featureGates map[string]bool) error {
+	for name := range featureGates {
+		if name == "" || strings.ContainsAny(name, "=, ") {
+			return fmt.Errorf("invalid feature gate name %q", name)
+		}
+	}
+	return nil
+}
+
+// FeatureGates returns the default feature gates with the given overrides
+// applied. Defaults keep their order, and additional gates are appended in
+// alphabetical order.
+func FeatureGates(overrides map[string]bool) kcpv1.Arguments {
+	result := kcpv1.Arguments{}
+	seen := sets.NewString()
+	for _, gate := range DefaultFeatureGates {
+		name := strings.SplitN(gate, "=", 2)[0]
+		seen.Insert(name)
+		if enabled, ok := overrides[name]; ok {
+			gate = fmt.Sprintf("%s=%s", name, strconv.FormatBool(enabled))
+		}
+		result = append(result, gate)
+	}
+	for _, name := range sets.StringKeySet(overrides).List() {
+		if seen.Has(name) {
+			continue
+		}
+		result = append(result, fmt.Sprintf("%s=%s", name, strconv.FormatBool(overrides[name])))
+	}
+	return result
+}
@@ -1,14 +1,21 @@
 package kas
 
 import (
+	"fmt"
+
 	corev1 "k8s.io/api/core/v1"
+	auditpolicy "k8s.io/apiserver/pkg/audit/policy"
+
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
 )
 
 const (
 	AuditPolicyConfigMapKey = "policy.yaml"
 )
 
-const defaultAuditConfig = `
+// auditPolicyPreamble excludes noisy and sensitive requests from all
+// auditing profiles.
+const auditPolicyPreamble = `
 apiVersion: audit.k8s.io/v1beta1
 kind: Policy
 omitStages:
@@ -33,16 +40,86 @@ rules:
   - "/readyz"
   userGroups:
   - system:authenticated
-  - system:unauthenticated
+  - system:unauthenticated`
+
+// auditPolicySensitiveResources never logs the bodies of requests to resources
+// that hold credentials.
+const auditPolicySensitiveResources = `
+- level: Metadata
+  resources:
+  - group: ''
+    resources:
+    - secrets
+    - serviceaccounts/token
+  - group: authentication.k8s.io
+    resources:
+    - tokenreviews
+  - group: oauth.openshift.io
+    resources:
+    - oauthclients
+  - group: route.openshift.io
+    resources:
+    - routes`
+
+const defaultAuditConfig = auditPolicyPreamble + `
+- level: Metadata
+  omitStages:
+  - RequestReceived
+`
+
+const writeRequestBodiesAuditConfig = auditPolicyPreamble + auditPolicySensitiveResources + `
+- level: RequestResponse
+  verbs:
+  - update
+  - patch
+  - create
+  - delete
+  - deletecollection
 - level: Metadata
   omitStages:
   - RequestReceived
 `
 
-func ReconcileAuditConfig(auditCfgMap *corev1.ConfigMap) error {
+const allRequestBodiesAuditConfig = auditPolicyPreamble + auditPolicySensitiveResources + `
+- level: RequestResponse
+  omitStages:
+  - RequestReceived
+`
+
+const noneAuditConfig = `
+apiVersion: audit.k8s.io/v1beta1
+kind: Policy
+rules:
+- level: None
+`
+
+// AuditPolicy returns the audit policy of a profile.
+func AuditPolicy(profile hyperlitev1.AuditProfileType) string {
+	switch profile {
+	case hyperlitev1.NoneAuditProfile:
+		return noneAuditConfig
+	case hyperlitev1.WriteRequestBodiesAuditProfile:
+		return writeRequestBodiesAuditConfig
+	case hyperlitev1.AllRequestBodiesAuditProfile:
+		return allRequestBodiesAuditConfig
+	default:
+		return defaultAuditConfig
+	}
+}
+
+// ValidateAuditPolicy verifies that a custom audit policy can be loaded by the
+// API server.
+func ValidateAuditPolicy(policy string) error {
+	if _, err := auditpolicy.LoadPolicyFromBytes([]byte(policy)); err != nil {
+		return fmt.Errorf("invalid audit policy: %w", err)
+	}
+	return nil
+}
+
+func ReconcileAuditConfig(auditCfgMap *corev1.ConfigMap, policy string) error {
 	if auditCfgMap.Data == nil {
 		auditCfgMap.Data = map[string]string{}
 	}
-	auditCfgMap.Data[AuditPolicyConfigMapKey] = defaultAuditConfig
+	auditCfgMap.Data[AuditPolicyConfigMapKey] = policy
 	return nil
 }
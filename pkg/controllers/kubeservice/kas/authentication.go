@@ -0,0 +1,102 @@
+package kas
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kcpv1 "github.com/openshift/api/kubecontrolplane/v1"
+
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/pki"
+)
+
+const (
+	oidcCAVolume                  = "oidc-ca"
+	authnWebhookKubeconfigVolume  = "authn-webhook-kubeconfig"
+	kasOIDCCAMountPath            = "/etc/kubernetes/certs/oidc-ca"
+	kasAuthnWebhookKubeconfigPath = "/etc/kubernetes/secrets/authn-webhook"
+	defaultUsernameClaim          = "sub"
+)
+
+// ValidateAuthentication verifies the authenticators of the API server.
+func ValidateAuthentication(authentication hyperlitev1.AuthenticationSpec) error {
+	if oidc := authentication.OIDC; oidc != nil {
+		issuerURL, err := url.Parse(oidc.IssuerURL)
+		if err != nil {
+			return fmt.Errorf("invalid OIDC issuer URL %q: %w", oidc.IssuerURL, err)
+		}
+		if issuerURL.Scheme != "https" || issuerURL.Host == "" {
+			return fmt.Errorf("invalid OIDC issuer URL %q: must be an https URL", oidc.IssuerURL)
+		}
+		if oidc.ClientID == "" {
+			return fmt.Errorf("OIDC client ID is required")
+		}
+		if oidc.CA != nil && oidc.CA.Name == "" {
+			return fmt.Errorf("OIDC CA secret name is required")
+		}
+	}
+	if webhook := authentication.Webhook; webhook != nil {
+		if webhook.KubeConfig.Name == "" {
+			return fmt.Errorf("authentication webhook kubeconfig secret name is required")
+		}
+		if err := validateTTL("authentication webhook cacheTTL", webhook.CacheTTL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateTTL(name string, ttl *metav1.Duration) error {
+	if ttl != nil && ttl.Duration < 0 {
+		return fmt.Errorf("%s must not be negative", name)
+	}
+	return nil
+}
+
+// authenticationArgs returns the API server arguments of the authenticators.
+func authenticationArgs(authentication hyperlitev1.AuthenticationSpec) map[string]kcpv1.Arguments {
+	args := map[string]kcpv1.Arguments{}
+	if oidc := authentication.OIDC; oidc != nil {
+		usernameClaim := oidc.UsernameClaim
+		if usernameClaim == "" {
+			usernameClaim = defaultUsernameClaim
+		}
+		args["oidc-issuer-url"] = kcpv1.Arguments{oidc.IssuerURL}
+		args["oidc-client-id"] = kcpv1.Arguments{oidc.ClientID}
+		args["oidc-username-claim"] = kcpv1.Arguments{usernameClaim}
+		if oidc.UsernamePrefix != "" {
+			args["oidc-username-prefix"] = kcpv1.Arguments{oidc.UsernamePrefix}
+		}
+		if oidc.GroupsClaim != "" {
+			args["oidc-groups-claim"] = kcpv1.Arguments{oidc.GroupsClaim}
+		}
+		if oidc.GroupsPrefix != "" {
+			args["oidc-groups-prefix"] = kcpv1.Arguments{oidc.GroupsPrefix}
+		}
+		if oidc.CA != nil {
+			args["oidc-ca-file"] = kcpv1.Arguments{path.Join(kasOIDCCAMountPath, pki.CASignerCertMapKey)}
+		}
+	}
+	if webhook := authentication.Webhook; webhook != nil {
+		args["authentication-token-webhook-config-file"] = kcpv1.Arguments{path.Join(kasAuthnWebhookKubeconfigPath, KubeconfigKey)}
+		if webhook.CacheTTL != nil {
+			args["authentication-token-webhook-cache-ttl"] = kcpv1.Arguments{webhook.CacheTTL.Duration.String()}
+		}
+	}
+	return args
+}
+
+// applyAuthentication mounts the files referenced by the authenticators in the
+// kube-apiserver container.
+func applyAuthentication(podSpec *corev1.PodSpec, authentication hyperlitev1.AuthenticationSpec) {
+	if oidc := authentication.OIDC; oidc != nil && oidc.CA != nil {
+		addSecretVolume(podSpec, oidcCAVolume, oidc.CA.Name, kasOIDCCAMountPath)
+	}
+	if webhook := authentication.Webhook; webhook != nil {
+		addSecretVolume(podSpec, authnWebhookKubeconfigVolume, webhook.KubeConfig.Name, kasAuthnWebhookKubeconfigPath)
+	}
+}
@@ -0,0 +1,62 @@
+package kas
+
+import (
+	"fmt"
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+
+	kcpv1 "github.com/openshift/api/kubecontrolplane/v1"
+
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
+)
+
+const (
+	authzWebhookKubeconfigVolume  = "authz-webhook-kubeconfig"
+	kasAuthzWebhookKubeconfigPath = "/etc/kubernetes/secrets/authz-webhook"
+)
+
+var defaultAuthorizationModes = kcpv1.Arguments{"Scope", "SystemMasters", "RBAC", "Node"}
+
+// ValidateAuthorization verifies the authorizers of the API server.
+func ValidateAuthorization(authorization hyperlitev1.AuthorizationSpec) error {
+	if webhook := authorization.Webhook; webhook != nil {
+		if webhook.KubeConfig.Name == "" {
+			return fmt.Errorf("authorization webhook kubeconfig secret name is required")
+		}
+		if err := validateTTL("authorization webhook authorizedTTL", webhook.AuthorizedTTL); err != nil {
+			return err
+		}
+		if err := validateTTL("authorization webhook unauthorizedTTL", webhook.UnauthorizedTTL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// authorizationArgs returns the API server arguments of the authorizers. The
+// webhook authorizer is consulted after the built-in authorizers.
+func authorizationArgs(authorization hyperlitev1.AuthorizationSpec) map[string]kcpv1.Arguments {
+	modes := append(kcpv1.Arguments{}, defaultAuthorizationModes...)
+	args := map[string]kcpv1.Arguments{}
+	if webhook := authorization.Webhook; webhook != nil {
+		modes = append(modes, "Webhook")
+		args["authorization-webhook-config-file"] = kcpv1.Arguments{path.Join(kasAuthzWebhookKubeconfigPath, KubeconfigKey)}
+		if webhook.AuthorizedTTL != nil {
+			args["authorization-webhook-cache-authorized-ttl"] = kcpv1.Arguments{webhook.AuthorizedTTL.Duration.String()}
+		}
+		if webhook.UnauthorizedTTL != nil {
+			args["authorization-webhook-cache-unauthorized-ttl"] = kcpv1.Arguments{webhook.UnauthorizedTTL.Duration.String()}
+		}
+	}
+	args["authorization-mode"] = modes
+	return args
+}
+
+// applyAuthorization mounts the files referenced by the authorizers in the
+// kube-apiserver container.
+func applyAuthorization(podSpec *corev1.PodSpec, authorization hyperlitev1.AuthorizationSpec) {
+	if webhook := authorization.Webhook; webhook != nil {
+		addSecretVolume(podSpec, authzWebhookKubeconfigVolume, webhook.KubeConfig.Name, kasAuthzWebhookKubeconfigPath)
+	}
+}
@@ -5,9 +5,11 @@ import (
 	"crypto/x509/pkix"
 	"fmt"
 	"net"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
 	"github.com/openshift-hive/hypershiftlite/pkg/certs"
 	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/pki"
 )
@@ -18,14 +20,14 @@ const (
 	ServiceSignerPublicKey  = "service-account.pub"
 )
 
-func ReconcileServerCertSecret(secret, ca *corev1.Secret, serviceCIDR string) error {
+func ReconcileServerCertSecret(secret, ca *corev1.Secret, instance, serviceCIDR, clusterDomain, externalHost string) error {
 	if !pki.ValidCA(ca) {
 		return fmt.Errorf("Invalid CA signer secret %s", ca.Name)
 	}
-	svc := Service(secret.Namespace)
+	svc := Service(secret.Namespace, instance)
 	secret.Type = corev1.SecretTypeTLS
 	expectedKeys := []string{corev1.TLSCertKey, corev1.TLSPrivateKeyKey}
-	if !pki.SignedSecretUpToDate(secret, ca, expectedKeys) {
+	if !pki.SignedSecretUpToDate(secret, ca, expectedKeys) || !certificateHasHost(secret.Data[corev1.TLSCertKey], externalHost) {
 		serviceName := svc.Name
 		serviceNamespace := svc.Namespace
 		_, serviceIPNet, err := net.ParseCIDR(serviceCIDR)
@@ -37,7 +39,7 @@ func ReconcileServerCertSecret(secret, ca *corev1.Secret, serviceCIDR string) er
 			"localhost",
 			"kubernetes",
 			"kubernetes.default.svc",
-			"kubernetes.default.svc.cluster.local",
+			fmt.Sprintf("kubernetes.default.svc.%s", clusterDomain),
 			serviceName,
 			fmt.Sprintf("%s.%s.svc", serviceName, serviceNamespace),
 			fmt.Sprintf("%s.%s.svc.cluster.local", serviceName, serviceNamespace),
@@ -46,6 +48,11 @@ func ReconcileServerCertSecret(secret, ca *corev1.Secret, serviceCIDR string) er
 			net.ParseIP("127.0.0.1"),
 			serviceIP,
 		}
+		if ip := net.ParseIP(externalHost); ip != nil {
+			apiServerIPs = append(apiServerIPs, ip)
+		} else if externalHost != "" {
+			dnsNames = append(dnsNames, externalHost)
+		}
 		cfg := &certs.CertCfg{
 			Subject:      pkix.Name{CommonName: "kubernetes", Organization: []string{"kubernetes"}},
 			KeyUsages:    x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
@@ -117,6 +124,39 @@ func ReconcileServiceAccountSigningKeySecret(secret *corev1.Secret) error {
 	return nil
 }
 
+// certificateHasHost returns true if the PEM encoded certificate is valid for
+// the given host name or IP address, or if no host is given.
+func certificateHasHost(crtBytes []byte, host string) bool {
+	if host == "" {
+		return true
+	}
+	crt, err := certs.PemToCertificate(crtBytes)
+	if err != nil {
+		return false
+	}
+	return crt.VerifyHostname(host) == nil
+}
+
+// NamedCertServesHost returns true if a named serving certificate is served for
+// the given host. The certificate secret is used to determine its names when
+// none are specified.
+func NamedCertServesHost(namedCert hyperlitev1.APIServerNamedServingCert, secret *corev1.Secret, host string) bool {
+	if len(namedCert.Names) == 0 {
+		return certificateHasHost(secret.Data[corev1.TLSCertKey], host)
+	}
+	for _, name := range namedCert.Names {
+		if name == host {
+			return true
+		}
+		if strings.HasPrefix(name, "*.") {
+			if i := strings.Index(host, "."); i > 0 && host[i:] == name[1:] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func nextIP(ip net.IP) net.IP {
 	nextIP := net.IP(make([]byte, len(ip)))
 	copy(nextIP, ip)
@@ -1,18 +1,22 @@
 package kas
 
 import (
+	"crypto/md5"
 	"encoding/json"
 	"fmt"
 	"path"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	configv1 "github.com/openshift/api/config/v1"
 	kcpv1 "github.com/openshift/api/kubecontrolplane/v1"
 
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
 	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/etcd"
 	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/pki"
 )
@@ -22,6 +26,11 @@ const (
 	OauthMetadataConfigKey = "oauthMetadata.json"
 	AuditLogFile           = "audit.log"
 	DefaultEtcdPort        = 2379
+	ConfigHashAnnotation   = "hypershiftlite.openshift.io/config-hash"
+
+	// EncryptionConfigHashAnnotation is the hash of the encryption
+	// configuration the API server pods run with.
+	EncryptionConfigHashAnnotation = "hypershiftlite.openshift.io/encryption-config-hash"
 )
 
 const oauthMetadata = `{
@@ -62,15 +71,12 @@ var (
 	}
 )
 
-func ReconcileConfig(config *corev1.ConfigMap, serviceCIDR string, internalAPIServerPort int) error {
+func ReconcileConfig(config *corev1.ConfigMap, params ConfigParams) error {
 	if config.Data == nil {
 		config.Data = map[string]string{}
 	}
-	serializedConfig, err := generateConfig(&ConfigParams{
-		InternalAPIServerPort: internalAPIServerPort,
-		Namespace:             config.Namespace,
-		ServiceCIDR:           serviceCIDR,
-	})
+	params.Namespace = config.Namespace
+	serializedConfig, err := generateConfig(&params)
 	if err != nil {
 		return fmt.Errorf("failed to create apiserver config: %w", err)
 	}
@@ -78,6 +84,26 @@ func ReconcileConfig(config *corev1.ConfigMap, serviceCIDR string, internalAPISe
 	return nil
 }
 
+// ConfigHash returns a hash of the content of the given config maps and
+// secrets. It is added to the API server pod template so that pods are rolled
+// out when their configuration changes.
+func ConfigHash(objects ...metav1.Object) string {
+	hash := md5.New()
+	for _, obj := range objects {
+		switch o := obj.(type) {
+		case *corev1.ConfigMap:
+			for _, key := range sets.StringKeySet(o.Data).List() {
+				fmt.Fprintf(hash, "%s/%s=%s\n", o.Name, key, o.Data[key])
+			}
+		case *corev1.Secret:
+			for _, key := range sets.StringKeySet(o.Data).List() {
+				fmt.Fprintf(hash, "%s/%s=%x\n", o.Name, key, o.Data[key])
+			}
+		}
+	}
+	return fmt.Sprintf("%x", hash.Sum(nil))
+}
+
 func ReconcileOauthMetadata(cfg *corev1.ConfigMap) error {
 	if cfg.Data == nil {
 		cfg.Data = map[string]string{}
@@ -89,73 +115,38 @@ func ReconcileOauthMetadata(cfg *corev1.ConfigMap) error {
 type ConfigParams struct {
 	InternalAPIServerPort int
 	Namespace             string
+	Instance              string
 	ServiceCIDR           string
+	PodCIDR               string
+	AdvertiseAddress      string
+	NamedCerts            []hyperlitev1.APIServerNamedServingCert
+	ExtraArgs             map[string]string
+	FeatureGates          map[string]bool
+	Admission             hyperlitev1.AdmissionSpec
+	Authentication        hyperlitev1.AuthenticationSpec
+	Authorization         hyperlitev1.AuthorizationSpec
+	SecretEncryption      bool
 }
 
 func generateConfig(params *ConfigParams) (string, error) {
+	enabledAdmissionPlugins, disabledAdmissionPlugins := AdmissionPlugins(params.Admission)
 	config := kcpv1.KubeAPIServerConfig{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "KubeAPIServerConfig",
 			APIVersion: kcpv1.GroupVersion.String(),
 		},
 		APIServerArguments: map[string]kcpv1.Arguments{
-			"advertise-address":   {"172.20.0.1"},
-			"allow-privileged":    {"true"},
-			"anonymous-auth":      {"true"},
-			"api-audiences":       {"https://kubernetes.default.svc"},
-			"audit-log-format":    {"json"},
-			"audit-log-maxbackup": {"10"},
-			"audit-log-maxsize":   {"100"},
-			"audit-log-path":      {path.Join(kasWorkLogsMountPath, AuditLogFile)},
-			"audit-policy-file":   {path.Join(kasAuditConfigMountPath, AuditPolicyConfigMapKey)},
-			"authorization-mode":  {"Scope", "SystemMasters", "RBAC", "Node"},
-			"client-ca-file":      {path.Join(kasRootCAMountPath, pki.CASignerCertMapKey)},
-			"enable-admission-plugins": {
-				"CertificateApproval",
-				"CertificateSigning",
-				"CertificateSubjectRestriction",
-				"DefaultIngressClass",
-				"DefaultStorageClass",
-				"DefaultTolerationSeconds",
-				"LimitRanger",
-				"MutatingAdmissionWebhook",
-				"NamespaceLifecycle",
-				"NodeRestriction",
-				"OwnerReferencesPermissionEnforcement",
-				"PersistentVolumeClaimResize",
-				"PersistentVolumeLabel",
-				"PodNodeSelector",
-				"PodTolerationRestriction",
-				"Priority",
-				"ResourceQuota",
-				"RuntimeClass",
-				"ServiceAccount",
-				"StorageObjectInUseProtection",
-				"TaintNodesByCondition",
-				"ValidatingAdmissionWebhook",
-				"authorization.openshift.io/RestrictSubjectBindings",
-				"authorization.openshift.io/ValidateRoleBindingRestriction",
-				"config.openshift.io/DenyDeleteClusterConfiguration",
-				"config.openshift.io/ValidateAPIServer",
-				"config.openshift.io/ValidateAuthentication",
-				"config.openshift.io/ValidateConsole",
-				"config.openshift.io/ValidateFeatureGate",
-				"config.openshift.io/ValidateImage",
-				"config.openshift.io/ValidateOAuth",
-				"config.openshift.io/ValidateProject",
-				"config.openshift.io/ValidateScheduler",
-				"image.openshift.io/ImagePolicy",
-				"network.openshift.io/ExternalIPRanger",
-				"network.openshift.io/RestrictedEndpointsAdmission",
-				"quota.openshift.io/ClusterResourceQuota",
-				"quota.openshift.io/ValidateClusterResourceQuota",
-				"route.openshift.io/IngressAdmission",
-				"scheduling.openshift.io/OriginPodNodeEnvironment",
-				"security.openshift.io/DefaultSecurityContextConstraints",
-				"security.openshift.io/SCCExecRestrictions",
-				"security.openshift.io/SecurityContextConstraint",
-				"security.openshift.io/ValidateSecurityContextConstraints",
-			},
+			"advertise-address":                {params.AdvertiseAddress},
+			"allow-privileged":                 {"true"},
+			"anonymous-auth":                   {"true"},
+			"api-audiences":                    {"https://kubernetes.default.svc"},
+			"audit-log-format":                 {"json"},
+			"audit-log-maxbackup":              {"10"},
+			"audit-log-maxsize":                {"100"},
+			"audit-log-path":                   {path.Join(kasWorkLogsMountPath, AuditLogFile)},
+			"audit-policy-file":                {path.Join(kasAuditConfigMountPath, AuditPolicyConfigMapKey)},
+			"client-ca-file":                   {path.Join(kasRootCAMountPath, pki.CASignerCertMapKey)},
+			"enable-admission-plugins":         enabledAdmissionPlugins,
 			"enable-aggregator-routing":        {"true"},
 			"enable-logs-handler":              {"false"},
 			"enable-swagger-ui":                {"true"},
@@ -164,9 +155,9 @@ func generateConfig(params *ConfigParams) (string, error) {
 			"etcd-certfile":                    {path.Join(kasEtcdClientCertMountPath, etcd.ClientCrtKey)},
 			"etcd-keyfile":                     {path.Join(kasEtcdClientCertMountPath, etcd.ClientKeyKey)},
 			"etcd-prefix":                      {"kubernetes.io"},
-			"etcd-servers":                     {fmt.Sprintf("https://%s-client:%d", etcd.Cluster(params.Namespace).Name, DefaultEtcdPort)},
+			"etcd-servers":                     {fmt.Sprintf("https://%s-client:%d", etcd.Cluster(params.Namespace, params.Instance).Name, DefaultEtcdPort)},
 			"event-ttl":                        {"3h"},
-			"feature-gates":                    DefaultFeatureGates,
+			"feature-gates":                    FeatureGates(params.FeatureGates),
 			"goaway-chance":                    {"0"},
 			"http2-max-streams-per-connection": {"2000"},
 			"insecure-port":                    {"0"},
@@ -198,20 +189,7 @@ func generateConfig(params *ConfigParams) (string, error) {
 		},
 		GenericAPIServerConfig: configv1.GenericAPIServerConfig{
 			AdmissionConfig: configv1.AdmissionConfig{
-				PluginConfig: map[string]configv1.AdmissionPluginConfig{
-					"network.openshift.io/ExternalIPRanger": {
-						Location: "",
-						Configuration: runtime.RawExtension{
-							Object: externalIPRangerConfig(),
-						},
-					},
-					"network.openshift.io/RestrictedEndpointsAdmission": {
-						Location: "",
-						Configuration: runtime.RawExtension{
-							Object: restrictedEndpointsAdmission(params.ServiceCIDR),
-						},
-					},
-				},
+				PluginConfig: admissionPluginConfig(params, enabledAdmissionPlugins),
 			},
 			ServingInfo: configv1.HTTPServingInfo{
 				ServingInfo: configv1.ServingInfo{
@@ -246,6 +224,24 @@ func generateConfig(params *ConfigParams) (string, error) {
 		ServiceAccountPublicKeyFiles: []string{path.Join(kasServiceAccountKeyMountPath, ServiceSignerPublicKey)},
 		ServicesSubnet:               params.ServiceCIDR,
 	}
+	if len(disabledAdmissionPlugins) > 0 {
+		config.APIServerArguments["disable-admission-plugins"] = disabledAdmissionPlugins
+	}
+	for name, value := range authenticationArgs(params.Authentication) {
+		config.APIServerArguments[name] = value
+	}
+	for name, value := range authorizationArgs(params.Authorization) {
+		config.APIServerArguments[name] = value
+	}
+	if params.SecretEncryption {
+		config.APIServerArguments["encryption-provider-config"] = kcpv1.Arguments{path.Join(kasEncryptionConfigMountPath, EncryptionConfigKey)}
+	}
+	if len(params.NamedCerts) > 0 {
+		config.APIServerArguments["tls-sni-cert-key"] = namedCertArgs(params.NamedCerts)
+	}
+	for name, value := range params.ExtraArgs {
+		config.APIServerArguments[name] = kcpv1.Arguments{value}
+	}
 	result, err := json.Marshal(config)
 	if err != nil {
 		return "", err
@@ -253,6 +249,21 @@ func generateConfig(params *ConfigParams) (string, error) {
 	return string(result), nil
 }
 
+// namedCertArgs returns the tls-sni-cert-key values for the named serving
+// certificates mounted in the API server pod.
+func namedCertArgs(namedCerts []hyperlitev1.APIServerNamedServingCert) kcpv1.Arguments {
+	var args kcpv1.Arguments
+	for i, namedCert := range namedCerts {
+		mountPath := namedCertMountPath(i)
+		arg := fmt.Sprintf("%s,%s", path.Join(mountPath, corev1.TLSCertKey), path.Join(mountPath, corev1.TLSPrivateKeyKey))
+		if len(namedCert.Names) > 0 {
+			arg = fmt.Sprintf("%s:%s", arg, strings.Join(namedCert.Names, ","))
+		}
+		args = append(args, arg)
+	}
+	return args
+}
+
 func externalIPRangerConfig() runtime.Object {
 	cfg := &unstructured.Unstructured{}
 	cfg.SetAPIVersion("network.openshift.io/v1")
@@ -261,10 +272,10 @@ func externalIPRangerConfig() runtime.Object {
 	return cfg
 }
 
-func restrictedEndpointsAdmission(serviceCIDR string) runtime.Object {
+func restrictedEndpointsAdmission(serviceCIDR, podCIDR string) runtime.Object {
 	cfg := &unstructured.Unstructured{}
 	cfg.SetAPIVersion("network.openshift.io/v1")
 	cfg.SetKind("RestrictedEndpointsAdmissionConfig")
-	unstructured.SetNestedStringSlice(cfg.Object, []string{serviceCIDR}, "restrictedCIDRs")
+	unstructured.SetNestedStringSlice(cfg.Object, []string{serviceCIDR, podCIDR}, "restrictedCIDRs")
 	return cfg
 }
@@ -3,14 +3,18 @@ package kas
 import (
 	"fmt"
 	"path"
+	"strconv"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/pointer"
 
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
 	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/etcd"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/ks"
 	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/pki"
 )
 
@@ -32,6 +36,8 @@ const (
 	serviceAccountKeyVolume   = "svcacct-key"
 	etcdClientCertVolume      = "etcd-client-crt"
 	oauthMetadataVolume       = "oauth-metadata"
+	namedCertVolumePrefix     = "named-crt-"
+	encryptionConfigVolume    = "encryption-config"
 
 	// volume mounts in init bootstrap container
 	initWorkMountPath = "/work" // manifests are saved here to be later applied by apply-bootstrap
@@ -50,26 +56,48 @@ const (
 	kasEtcdClientCertMountPath    = "/etc/kubernetes/certs/etcd"
 	kasServiceAccountKeyMountPath = "/etc/kubernetes/secrets/svcacct-key"
 	kasOauthMetadataMountPath     = "/etc/kubernetes/oauth"
+	kasNamedCertsMountPath        = "/etc/kubernetes/certs/named"
+	kasEncryptionConfigMountPath  = "/etc/kubernetes/secrets/encryption-config"
 )
 
-var kasLabels = map[string]string{
-	"app": "kube-apiserver",
+func kasLabels(instance string) map[string]string {
+	return map[string]string{
+		"app": ks.Name(instance, "kube-apiserver"),
+	}
+}
+
+var kasDefaultResources = corev1.ResourceRequirements{
+	Requests: corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("350m"),
+		corev1.ResourceMemory: resource.MustParse("1Gi"),
+	},
+}
+
+type DeploymentParams struct {
+	Instance              string
+	ConfigOperatorImage   string
+	CLIImage              string
+	HyperKubeImage        string
+	InternalAPIServerPort int
+	Replicas              int
+	Component             *hyperlitev1.ComponentSpec
+	NamedCerts            []hyperlitev1.APIServerNamedServingCert
+	Authentication        hyperlitev1.AuthenticationSpec
+	Authorization         hyperlitev1.AuthorizationSpec
+	SecretEncryption      bool
+	KMSPlugin             *hyperlitev1.KMSSpec
+	ConfigHash            string
+	EncryptionConfigHash  string
 }
 
-func ReconcileKubeAPIServerDeployment(
-	deployment *appsv1.Deployment,
-	configOperatorImage string,
-	cliImage string,
-	hyperKubeImage string,
-	internalAPIServerPort int,
-	replicaCount int,
-) error {
+func ReconcileKubeAPIServerDeployment(deployment *appsv1.Deployment, params DeploymentParams) error {
+	instance := params.Instance
 	maxSurge := intstr.FromInt(3)
 	maxUnavailable := intstr.FromInt(1)
 	deployment.Spec = appsv1.DeploymentSpec{
-		Replicas: pointer.Int32Ptr(int32(replicaCount)),
+		Replicas: pointer.Int32Ptr(int32(params.Replicas)),
 		Selector: &metav1.LabelSelector{
-			MatchLabels: kasLabels,
+			MatchLabels: kasLabels(instance),
 		},
 		Strategy: appsv1.DeploymentStrategy{
 			Type: appsv1.RollingUpdateDeploymentStrategyType,
@@ -80,14 +108,17 @@ func ReconcileKubeAPIServerDeployment(
 		},
 		Template: corev1.PodTemplateSpec{
 			ObjectMeta: metav1.ObjectMeta{
-				Labels: kasLabels,
+				Labels: kasLabels(instance),
+				Annotations: map[string]string{
+					ConfigHashAnnotation: params.ConfigHash,
+				},
 			},
 			Spec: corev1.PodSpec{
 				AutomountServiceAccountToken: pointer.BoolPtr(false),
 				InitContainers: []corev1.Container{
 					{
 						Name:  initBootstrapContainer,
-						Image: configOperatorImage,
+						Image: params.ConfigOperatorImage,
 						Command: []string{
 							"/bin/bash",
 						},
@@ -106,7 +137,7 @@ func ReconcileKubeAPIServerDeployment(
 				Containers: []corev1.Container{
 					{
 						Name:  applyBootstrapManifestsContainer,
-						Image: cliImage,
+						Image: params.CLIImage,
 						Command: []string{
 							"/bin/bash",
 						},
@@ -133,7 +164,7 @@ func ReconcileKubeAPIServerDeployment(
 					},
 					{
 						Name:  kubeAPIServerContainer,
-						Image: hyperKubeImage,
+						Image: params.HyperKubeImage,
 						Command: []string{
 							"hyperkube",
 						},
@@ -150,7 +181,7 @@ func ReconcileKubeAPIServerDeployment(
 								HTTPGet: &corev1.HTTPGetAction{
 									Path:   "/livez",
 									Scheme: corev1.URISchemeHTTPS,
-									Port:   intstr.FromInt(internalAPIServerPort),
+									Port:   intstr.FromInt(params.InternalAPIServerPort),
 								},
 							},
 						},
@@ -161,7 +192,7 @@ func ReconcileKubeAPIServerDeployment(
 								HTTPGet: &corev1.HTTPGetAction{
 									Path:   "/healthz",
 									Scheme: corev1.URISchemeHTTPS,
-									Port:   intstr.FromInt(internalAPIServerPort),
+									Port:   intstr.FromInt(params.InternalAPIServerPort),
 								},
 							},
 						},
@@ -216,7 +247,7 @@ func ReconcileKubeAPIServerDeployment(
 						Name: localhostKubeconfigVolume,
 						VolumeSource: corev1.VolumeSource{
 							Secret: &corev1.SecretVolumeSource{
-								SecretName: LocalhostKubeconfigSecret(deployment.Namespace).Name,
+								SecretName: LocalhostKubeconfigSecret(deployment.Namespace, instance).Name,
 							},
 						},
 					},
@@ -231,7 +262,7 @@ func ReconcileKubeAPIServerDeployment(
 						VolumeSource: corev1.VolumeSource{
 							ConfigMap: &corev1.ConfigMapVolumeSource{
 								LocalObjectReference: corev1.LocalObjectReference{
-									Name: Config(deployment.Namespace).Name,
+									Name: Config(deployment.Namespace, instance).Name,
 								},
 							},
 						},
@@ -241,7 +272,7 @@ func ReconcileKubeAPIServerDeployment(
 						VolumeSource: corev1.VolumeSource{
 							ConfigMap: &corev1.ConfigMapVolumeSource{
 								LocalObjectReference: corev1.LocalObjectReference{
-									Name: AuditConfig(deployment.Namespace).Name,
+									Name: AuditConfig(deployment.Namespace, instance).Name,
 								},
 							},
 						},
@@ -250,7 +281,7 @@ func ReconcileKubeAPIServerDeployment(
 						Name: rootCAVolume,
 						VolumeSource: corev1.VolumeSource{
 							Secret: &corev1.SecretVolumeSource{
-								SecretName: pki.RootCASecret(deployment.Namespace).Name,
+								SecretName: pki.RootCASecret(deployment.Namespace, instance).Name,
 							},
 						},
 					},
@@ -258,7 +289,7 @@ func ReconcileKubeAPIServerDeployment(
 						Name: serverCertVolume,
 						VolumeSource: corev1.VolumeSource{
 							Secret: &corev1.SecretVolumeSource{
-								SecretName: ServerCertSecret(deployment.Namespace).Name,
+								SecretName: ServerCertSecret(deployment.Namespace, instance).Name,
 							},
 						},
 					},
@@ -266,7 +297,7 @@ func ReconcileKubeAPIServerDeployment(
 						Name: aggregatorCertVolume,
 						VolumeSource: corev1.VolumeSource{
 							Secret: &corev1.SecretVolumeSource{
-								SecretName: AggregatorCertSecret(deployment.Namespace).Name,
+								SecretName: AggregatorCertSecret(deployment.Namespace, instance).Name,
 							},
 						},
 					},
@@ -274,7 +305,7 @@ func ReconcileKubeAPIServerDeployment(
 						Name: serviceAccountKeyVolume,
 						VolumeSource: corev1.VolumeSource{
 							Secret: &corev1.SecretVolumeSource{
-								SecretName: ServiceAccountSigningKeySecret(deployment.Namespace).Name,
+								SecretName: ServiceAccountSigningKeySecret(deployment.Namespace, instance).Name,
 							},
 						},
 					},
@@ -282,7 +313,7 @@ func ReconcileKubeAPIServerDeployment(
 						Name: etcdClientCertVolume,
 						VolumeSource: corev1.VolumeSource{
 							Secret: &corev1.SecretVolumeSource{
-								SecretName: etcd.ClientSecret(deployment.Namespace).Name,
+								SecretName: etcd.ClientSecret(deployment.Namespace, instance).Name,
 							},
 						},
 					},
@@ -291,7 +322,7 @@ func ReconcileKubeAPIServerDeployment(
 						VolumeSource: corev1.VolumeSource{
 							ConfigMap: &corev1.ConfigMapVolumeSource{
 								LocalObjectReference: corev1.LocalObjectReference{
-									Name: OAuthMetadata(deployment.Namespace).Name,
+									Name: OAuthMetadata(deployment.Namespace, instance).Name,
 								},
 							},
 						},
@@ -300,9 +331,55 @@ func ReconcileKubeAPIServerDeployment(
 			},
 		},
 	}
+	if params.Replicas > 1 {
+		deployment.Spec.Template.Spec.Affinity = ks.PodAntiAffinity(kasLabels(instance))
+	}
+	ks.ApplyComponentSpec(&deployment.Spec.Template.Spec, kubeAPIServerContainer, params.Component, kasDefaultResources)
+	applyNamedCerts(&deployment.Spec.Template.Spec, params.NamedCerts)
+	applyAuthentication(&deployment.Spec.Template.Spec, params.Authentication)
+	applyAuthorization(&deployment.Spec.Template.Spec, params.Authorization)
+	if params.SecretEncryption {
+		deployment.Spec.Template.Annotations[EncryptionConfigHashAnnotation] = params.EncryptionConfigHash
+		addSecretVolume(&deployment.Spec.Template.Spec, encryptionConfigVolume, EncryptionConfigSecret(deployment.Namespace, instance).Name, kasEncryptionConfigMountPath)
+	}
+	if params.KMSPlugin != nil {
+		applyKMSPlugin(&deployment.Spec.Template.Spec, params.KMSPlugin)
+	}
 	return nil
 }
 
+// applyNamedCerts mounts the secrets of the named serving certificates in the
+// kube-apiserver container.
+func applyNamedCerts(podSpec *corev1.PodSpec, namedCerts []hyperlitev1.APIServerNamedServingCert) {
+	for i, namedCert := range namedCerts {
+		addSecretVolume(podSpec, namedCertVolumePrefix+strconv.Itoa(i), namedCert.ServingCertificate.Name, namedCertMountPath(i))
+	}
+}
+
+// addSecretVolume mounts a secret in the kube-apiserver container.
+func addSecretVolume(podSpec *corev1.PodSpec, volumeName, secretName, mountPath string) {
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: volumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: secretName,
+			},
+		},
+	})
+	for i := range podSpec.Containers {
+		if podSpec.Containers[i].Name == kubeAPIServerContainer {
+			podSpec.Containers[i].VolumeMounts = append(podSpec.Containers[i].VolumeMounts, corev1.VolumeMount{
+				Name:      volumeName,
+				MountPath: mountPath,
+			})
+		}
+	}
+}
+
+func namedCertMountPath(index int) string {
+	return path.Join(kasNamedCertsMountPath, strconv.Itoa(index))
+}
+
 func invokeMCORenderScript(workDir string) string {
 	var script = `#!/bin/sh
 cd /tmp
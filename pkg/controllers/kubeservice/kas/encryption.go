@@ -0,0 +1,119 @@
+package kas
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiserverconfigv1 "k8s.io/apiserver/pkg/apis/config/v1"
+	"sigs.k8s.io/yaml"
+
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
+)
+
+const (
+	EncryptionConfigKey = "config.yaml"
+
+	// encryptionKeyPrefix is the prefix of the encryption config secret keys
+	// that hold encryption keys.
+	encryptionKeyPrefix = "key-"
+	encryptionKeySize   = 32
+)
+
+// NewEncryptionKeyName returns a unique name for a new encryption key.
+func NewEncryptionKeyName(now metav1.Time) string {
+	return fmt.Sprintf("%s%d", encryptionKeyPrefix, now.Unix())
+}
+
+// ReconcileEncryptionConfigSecret generates any missing key material for the
+// given keys, drops the material of keys that are no longer used, and renders
+// the EncryptionConfiguration of the API server. Secrets written before
+// encryption was enabled remain readable through the identity provider. KMS
+// keys have no local key material and use the given KMS plugin settings.
+func ReconcileEncryptionConfigSecret(secret *corev1.Secret, keys []hyperlitev1.EncryptionKey, kms *hyperlitev1.KMSSpec) error {
+	secret.Type = corev1.SecretTypeOpaque
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	inUse := map[string]bool{EncryptionConfigKey: true}
+	var providers []apiserverconfigv1.ProviderConfiguration
+	for _, key := range keys {
+		if key.Type == hyperlitev1.KMSEncryption {
+			providers = append(providers, kmsProvider(key, kms))
+			continue
+		}
+		inUse[key.Name] = true
+		material, ok := secret.Data[key.Name]
+		if !ok {
+			material = make([]byte, encryptionKeySize)
+			if _, err := rand.Read(material); err != nil {
+				return fmt.Errorf("failed to generate encryption key: %w", err)
+			}
+			secret.Data[key.Name] = material
+		}
+		provider, err := encryptionProvider(key, material)
+		if err != nil {
+			return err
+		}
+		providers = append(providers, provider)
+	}
+	for name := range secret.Data {
+		if !inUse[name] {
+			delete(secret.Data, name)
+		}
+	}
+	providers = append(providers, apiserverconfigv1.ProviderConfiguration{
+		Identity: &apiserverconfigv1.IdentityConfiguration{},
+	})
+	config := apiserverconfigv1.EncryptionConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "EncryptionConfiguration",
+			APIVersion: apiserverconfigv1.SchemeGroupVersion.String(),
+		},
+		Resources: []apiserverconfigv1.ResourceConfiguration{
+			{
+				Resources: []string{"secrets"},
+				Providers: providers,
+			},
+		},
+	}
+	serializedConfig, err := yaml.Marshal(config)
+	if err != nil {
+		return fmt.Errorf("failed to serialize encryption config: %w", err)
+	}
+	secret.Data[EncryptionConfigKey] = serializedConfig
+	return nil
+}
+
+func kmsProvider(key hyperlitev1.EncryptionKey, kms *hyperlitev1.KMSSpec) apiserverconfigv1.ProviderConfiguration {
+	config := &apiserverconfigv1.KMSConfiguration{
+		Name:     key.Name,
+		Endpoint: "unix://" + kmsSocketPath,
+	}
+	if kms != nil {
+		config.CacheSize = kms.CacheSize
+		config.Timeout = kms.Timeout
+	}
+	return apiserverconfigv1.ProviderConfiguration{KMS: config}
+}
+
+func encryptionProvider(key hyperlitev1.EncryptionKey, material []byte) (apiserverconfigv1.ProviderConfiguration, error) {
+	keys := []apiserverconfigv1.Key{
+		{
+			Name:   key.Name,
+			Secret: base64.StdEncoding.EncodeToString(material),
+		},
+	}
+	switch key.Type {
+	case hyperlitev1.AESCBCEncryption:
+		return apiserverconfigv1.ProviderConfiguration{AESCBC: &apiserverconfigv1.AESConfiguration{Keys: keys}}, nil
+	case hyperlitev1.AESGCMEncryption:
+		return apiserverconfigv1.ProviderConfiguration{AESGCM: &apiserverconfigv1.AESConfiguration{Keys: keys}}, nil
+	case hyperlitev1.SecretboxEncryption:
+		return apiserverconfigv1.ProviderConfiguration{Secretbox: &apiserverconfigv1.SecretboxConfiguration{Keys: keys}}, nil
+	default:
+		return apiserverconfigv1.ProviderConfiguration{}, fmt.Errorf("unsupported encryption type %q", key.Type)
+	}
+}
@@ -0,0 +1,76 @@
+package kas
+
+import (
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
+)
+
+const (
+	kmsPluginContainer = "kms-plugin"
+
+	kmsSocketVolume      = "kms-socket"
+	kmsCredentialsVolume = "kms-credentials"
+
+	kmsSocketMountPath      = "/var/run/kms-plugin"
+	kmsCredentialsMountPath = "/etc/kms-plugin/credentials"
+
+	// KMSPluginSocketEnvVar is the environment variable holding the path of
+	// the unix socket the KMS plugin must serve on.
+	KMSPluginSocketEnvVar = "KMS_PLUGIN_SOCKET"
+)
+
+var kmsSocketPath = path.Join(kmsSocketMountPath, "kms.sock")
+
+// applyKMSPlugin adds the KMS plugin sidecar to the API server pod. The plugin
+// and the kube-apiserver container share the directory of the plugin socket.
+func applyKMSPlugin(podSpec *corev1.PodSpec, kms *hyperlitev1.KMSSpec) {
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: kmsSocketVolume,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	})
+	socketMount := corev1.VolumeMount{
+		Name:      kmsSocketVolume,
+		MountPath: kmsSocketMountPath,
+	}
+	for i := range podSpec.Containers {
+		if podSpec.Containers[i].Name == kubeAPIServerContainer {
+			podSpec.Containers[i].VolumeMounts = append(podSpec.Containers[i].VolumeMounts, socketMount)
+		}
+	}
+	container := corev1.Container{
+		Name:    kmsPluginContainer,
+		Image:   kms.Image,
+		Command: kms.Command,
+		Args:    kms.Args,
+		Env: append([]corev1.EnvVar{
+			{
+				Name:  KMSPluginSocketEnvVar,
+				Value: kmsSocketPath,
+			},
+		}, kms.Env...),
+		VolumeMounts: []corev1.VolumeMount{socketMount},
+	}
+	if kms.Resources != nil {
+		container.Resources = *kms.Resources
+	}
+	if kms.Credentials != nil {
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name: kmsCredentialsVolume,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: kms.Credentials.Name,
+				},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      kmsCredentialsVolume,
+			MountPath: kmsCredentialsMountPath,
+		})
+	}
+	podSpec.Containers = append(podSpec.Containers, container)
+}
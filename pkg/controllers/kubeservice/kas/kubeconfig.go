@@ -1,11 +1,15 @@
 package kas
 
 import (
+	"bytes"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"fmt"
+	"net"
+	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
 	clientcmd "k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 
@@ -17,21 +21,36 @@ const (
 	KubeconfigKey = "kubeconfig"
 )
 
-func ReconcileServiceKubeconfigSecret(secret, ca *corev1.Secret, port int) error {
-	svcURL := fmt.Sprintf("https://%s:%d", Service(secret.Namespace).Name, port)
-	return reconcileSystemAdminKubeconfig(secret, ca, svcURL)
+func ReconcileServiceKubeconfigSecret(secret, ca *corev1.Secret, instance string, port int) error {
+	svcURL := fmt.Sprintf("https://%s:%d", Service(secret.Namespace, instance).Name, port)
+	return reconcileSystemAdminKubeconfig(secret, ca, svcURL, ca.Data[pki.CASignerCertMapKey])
+}
+
+// ReconcileExportedServiceKubeconfigSecret generates a kubeconfig that
+// addresses the API server service by its fully qualified name, for use
+// outside of the control plane namespace.
+func ReconcileExportedServiceKubeconfigSecret(secret, ca *corev1.Secret, svc *corev1.Service, port int) error {
+	svcURL := fmt.Sprintf("https://%s.%s.svc:%d", svc.Name, svc.Namespace, port)
+	return reconcileSystemAdminKubeconfig(secret, ca, svcURL, ca.Data[pki.CASignerCertMapKey])
 }
 
 func ReconcileLocalhostKubeconfigSecret(secret, ca *corev1.Secret, port int) error {
-	return reconcileSystemAdminKubeconfig(secret, ca, fmt.Sprintf("https://localhost:%d", port))
+	return reconcileSystemAdminKubeconfig(secret, ca, fmt.Sprintf("https://localhost:%d", port), ca.Data[pki.CASignerCertMapKey])
+}
+
+// ReconcileExternalKubeconfigSecret generates a kubeconfig for the published
+// API server endpoint. The serverCA is the bundle clients use to verify the
+// serving certificate; when empty, clients rely on their system trust store.
+func ReconcileExternalKubeconfigSecret(secret, ca *corev1.Secret, host string, port int, serverCA []byte) error {
+	return reconcileSystemAdminKubeconfig(secret, ca, fmt.Sprintf("https://%s", net.JoinHostPort(host, strconv.Itoa(port))), serverCA)
 }
 
-func reconcileSystemAdminKubeconfig(secret, ca *corev1.Secret, url string) error {
+func reconcileSystemAdminKubeconfig(secret, ca *corev1.Secret, url string, serverCA []byte) error {
 	if !pki.ValidCA(ca) {
 		return fmt.Errorf("Invalid CA signer secret %s", ca.Name)
 	}
 	secret.Type = corev1.SecretTypeOpaque
-	if pki.SignedSecretUpToDate(secret, ca, []string{KubeconfigKey}) {
+	if pki.SignedSecretUpToDate(secret, ca, []string{KubeconfigKey}) && kubeconfigMatches(secret.Data[KubeconfigKey], url, serverCA) {
 		return nil
 	}
 
@@ -41,11 +60,11 @@ func reconcileSystemAdminKubeconfig(secret, ca *corev1.Secret, url string) error
 		ExtKeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
 		Validity:     certs.ValidityOneYear,
 	}
-	crtBytes, keyBytes, caBytes, err := pki.SignCertificate(cfg, ca)
+	crtBytes, keyBytes, _, err := pki.SignCertificate(cfg, ca)
 	if err != nil {
 		return fmt.Errorf("failed to create signed cert for kubeconfig: %w", err)
 	}
-	kubeCfgBytes, err := generateKubeConfig(url, crtBytes, keyBytes, caBytes)
+	kubeCfgBytes, err := generateKubeConfig(url, crtBytes, keyBytes, serverCA)
 	if err != nil {
 		return fmt.Errorf("failed to generate kubeconfig: %w", err)
 	}
@@ -57,6 +76,33 @@ func reconcileSystemAdminKubeconfig(secret, ca *corev1.Secret, url string) error
 	return nil
 }
 
+// kubeconfigMatches returns true if a serialized kubeconfig points to the given
+// server URL and trusts the given certificate authority bundle.
+func kubeconfigMatches(kubeconfig []byte, url string, serverCA []byte) bool {
+	cfg, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return false
+	}
+	cluster, ok := cfg.Clusters["cluster"]
+	if !ok {
+		return false
+	}
+	return cluster.Server == url && bytes.Equal(cluster.CertificateAuthorityData, serverCA)
+}
+
+// ServiceRESTConfig returns a client configuration for the API server from the
+// service kubeconfig. The service is addressed by its fully qualified name so
+// that the configuration can be used outside of the control plane namespace.
+func ServiceRESTConfig(secret *corev1.Secret, instance string, port int) (*rest.Config, error) {
+	cfg, err := clientcmd.RESTConfigFromKubeConfig(secret.Data[KubeconfigKey])
+	if err != nil {
+		return nil, fmt.Errorf("failed to load service kubeconfig: %w", err)
+	}
+	svc := Service(secret.Namespace, instance)
+	cfg.Host = fmt.Sprintf("https://%s.%s.svc:%d", svc.Name, svc.Namespace, port)
+	return cfg, nil
+}
+
 func generateKubeConfig(url string, crtBytes, keyBytes, caBytes []byte) ([]byte, error) {
 	kubeCfg := clientcmdapi.Config{
 		Kind:       "Config",
@@ -1,96 +1,146 @@
 package kas
 
 import (
+	routev1 "github.com/openshift/api/route/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/ks"
 )
 
-func ServerCertSecret(controlPlaneNamespace string) *corev1.Secret {
+func ServerCertSecret(controlPlaneNamespace, instance string) *corev1.Secret {
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "kas-server-crt",
+			Name:      ks.Name(instance, "kas-server-crt"),
 			Namespace: controlPlaneNamespace,
 		},
 	}
 }
 
-func AggregatorCertSecret(controlPlaneNamespace string) *corev1.Secret {
+func AggregatorCertSecret(controlPlaneNamespace, instance string) *corev1.Secret {
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "kas-aggregator-crt",
+			Name:      ks.Name(instance, "kas-aggregator-crt"),
 			Namespace: controlPlaneNamespace,
 		},
 	}
 }
 
-func ServiceAccountSigningKeySecret(controlPlaneNamespace string) *corev1.Secret {
+func ServiceAccountSigningKeySecret(controlPlaneNamespace, instance string) *corev1.Secret {
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "kas-sa-key",
+			Name:      ks.Name(instance, "kas-sa-key"),
 			Namespace: controlPlaneNamespace,
 		},
 	}
 }
 
-func LocalhostKubeconfigSecret(controlPlaneNamespace string) *corev1.Secret {
+func LocalhostKubeconfigSecret(controlPlaneNamespace, instance string) *corev1.Secret {
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "localhost-kubeconfig",
+			Name:      ks.Name(instance, "localhost-kubeconfig"),
 			Namespace: controlPlaneNamespace,
 		},
 	}
 }
 
-func ServiceKubeconfigSecret(controlPlaneNamespace string) *corev1.Secret {
+func EncryptionConfigSecret(controlPlaneNamespace, instance string) *corev1.Secret {
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "kubeconfig",
+			Name:      ks.Name(instance, "kas-encryption-config"),
 			Namespace: controlPlaneNamespace,
 		},
 	}
 }
 
-func Deployment(controlPlaneNamespace string) *appsv1.Deployment {
+func ExternalKubeconfigSecret(controlPlaneNamespace, instance string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ks.Name(instance, "external-kubeconfig"),
+			Namespace: controlPlaneNamespace,
+		},
+	}
+}
+
+func ServiceKubeconfigSecret(controlPlaneNamespace, instance string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ks.Name(instance, "kubeconfig"),
+			Namespace: controlPlaneNamespace,
+		},
+	}
+}
+
+func Deployment(controlPlaneNamespace, instance string) *appsv1.Deployment {
 	return &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "kube-apiserver",
+			Name:      ks.Name(instance, "kube-apiserver"),
 			Namespace: controlPlaneNamespace,
 		},
 	}
 }
 
-func AuditConfig(controlPlaneNamespace string) *corev1.ConfigMap {
+func AuditConfig(controlPlaneNamespace, instance string) *corev1.ConfigMap {
 	return &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "kas-audit-config",
+			Name:      ks.Name(instance, "kas-audit-config"),
 			Namespace: controlPlaneNamespace,
 		},
 	}
 }
 
-func Config(controlPlaneNamespace string) *corev1.ConfigMap {
+func Config(controlPlaneNamespace, instance string) *corev1.ConfigMap {
 	return &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "kas-config",
+			Name:      ks.Name(instance, "kas-config"),
 			Namespace: controlPlaneNamespace,
 		},
 	}
 }
 
-func Service(controlPlaneNamespace string) *corev1.Service {
+func Service(controlPlaneNamespace, instance string) *corev1.Service {
 	return &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "kube-apiserver",
+			Name:      ks.Name(instance, "kube-apiserver"),
 			Namespace: controlPlaneNamespace,
 		},
 	}
 }
 
-func OAuthMetadata(controlPlaneNamespace string) *corev1.ConfigMap {
+func Route(controlPlaneNamespace, instance string) *routev1.Route {
+	return &routev1.Route{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ks.Name(instance, "kube-apiserver"),
+			Namespace: controlPlaneNamespace,
+		},
+	}
+}
+
+func Ingress(controlPlaneNamespace, instance string) *networkingv1.Ingress {
+	return &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ks.Name(instance, "kube-apiserver"),
+			Namespace: controlPlaneNamespace,
+		},
+	}
+}
+
+func OAuthMetadata(controlPlaneNamespace, instance string) *corev1.ConfigMap {
 	return &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "oauth-metadata",
+			Name:      ks.Name(instance, "oauth-metadata"),
+			Namespace: controlPlaneNamespace,
+		},
+	}
+}
+
+func PodDisruptionBudget(controlPlaneNamespace, instance string) *policyv1beta1.PodDisruptionBudget {
+	return &policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ks.Name(instance, "kube-apiserver"),
 			Namespace: controlPlaneNamespace,
 		},
 	}
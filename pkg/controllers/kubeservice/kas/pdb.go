@@ -0,0 +1,17 @@
+package kas
+
+import (
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func ReconcilePodDisruptionBudget(pdb *policyv1beta1.PodDisruptionBudget, instance string) error {
+	maxUnavailable := intstr.FromInt(1)
+	pdb.Spec.Selector = &metav1.LabelSelector{
+		MatchLabels: kasLabels(instance),
+	}
+	pdb.Spec.MinAvailable = nil
+	pdb.Spec.MaxUnavailable = &maxUnavailable
+	return nil
+}
@@ -0,0 +1,106 @@
+package kas
+
+import (
+	routev1 "github.com/openshift/api/route/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
+)
+
+const (
+	// port used by clients when the API server is published through a
+	// Route or an Ingress
+	routerHTTPSPort = 443
+
+	ingressSSLPassthroughAnnotation = "nginx.ingress.kubernetes.io/ssl-passthrough"
+)
+
+func ReconcileRoute(route *routev1.Route, instance, hostname string) error {
+	if hostname != "" {
+		route.Spec.Host = hostname
+	}
+	route.Spec.To = routev1.RouteTargetReference{
+		Kind: "Service",
+		Name: Service(route.Namespace, instance).Name,
+	}
+	route.Spec.TLS = &routev1.TLSConfig{
+		Termination:                   routev1.TLSTerminationPassthrough,
+		InsecureEdgeTerminationPolicy: routev1.InsecureEdgeTerminationPolicyNone,
+	}
+	return nil
+}
+
+func ReconcileIngress(ingress *networkingv1.Ingress, instance, hostname string, ingressClassName *string, port int) error {
+	if ingress.Annotations == nil {
+		ingress.Annotations = map[string]string{}
+	}
+	ingress.Annotations[ingressSSLPassthroughAnnotation] = "true"
+	pathType := networkingv1.PathTypePrefix
+	ingress.Spec = networkingv1.IngressSpec{
+		IngressClassName: ingressClassName,
+		Rules: []networkingv1.IngressRule{
+			{
+				Host: hostname,
+				IngressRuleValue: networkingv1.IngressRuleValue{
+					HTTP: &networkingv1.HTTPIngressRuleValue{
+						Paths: []networkingv1.HTTPIngressPath{
+							{
+								Path:     "/",
+								PathType: &pathType,
+								Backend: networkingv1.IngressBackend{
+									Service: &networkingv1.IngressServiceBackend{
+										Name: Service(ingress.Namespace, instance).Name,
+										Port: networkingv1.ServiceBackendPort{
+											Number: int32(port),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	return nil
+}
+
+// ExternalAPIEndpoint returns the endpoint at which the API server is reachable
+// from outside the management cluster given its publishing strategy, or nil if
+// it is not published or the endpoint is not known yet.
+func ExternalAPIEndpoint(publishing hyperlitev1.APIServerPublishingSpec, svc *corev1.Service, route *routev1.Route, externalPort int) *hyperlitev1.APIEndpoint {
+	switch publishing.Type {
+	case hyperlitev1.NodePortPublishing:
+		if len(svc.Spec.Ports) == 0 || svc.Spec.Ports[0].NodePort == 0 {
+			return nil
+		}
+		return &hyperlitev1.APIEndpoint{Host: publishing.Hostname, Port: svc.Spec.Ports[0].NodePort}
+	case hyperlitev1.LoadBalancerPublishing:
+		host := publishing.Hostname
+		if host == "" {
+			for _, ingress := range svc.Status.LoadBalancer.Ingress {
+				if ingress.Hostname != "" {
+					host = ingress.Hostname
+					break
+				}
+				if ingress.IP != "" {
+					host = ingress.IP
+					break
+				}
+			}
+		}
+		if host == "" {
+			return nil
+		}
+		return &hyperlitev1.APIEndpoint{Host: host, Port: int32(externalPort)}
+	case hyperlitev1.RoutePublishing:
+		if route == nil || route.Spec.Host == "" {
+			return nil
+		}
+		return &hyperlitev1.APIEndpoint{Host: route.Spec.Host, Port: routerHTTPSPort}
+	case hyperlitev1.IngressPublishing:
+		return &hyperlitev1.APIEndpoint{Host: publishing.Hostname, Port: routerHTTPSPort}
+	}
+	return nil
+}
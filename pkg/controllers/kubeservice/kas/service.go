@@ -3,9 +3,11 @@ package kas
 import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
 )
 
-func ReconcileService(svc *corev1.Service, internalPort, externalPort int) error {
+func ReconcileService(svc *corev1.Service, instance string, internalPort, externalPort int, publishing hyperlitev1.APIServerPublishingSpec) error {
 	if len(svc.Spec.Ports) > 0 {
 		svc.Spec.Ports[0].Port = int32(externalPort)
 		svc.Spec.Ports[0].TargetPort = intstr.FromInt(internalPort)
@@ -17,7 +19,18 @@ func ReconcileService(svc *corev1.Service, internalPort, externalPort int) error
 			},
 		}
 	}
-	svc.Spec.Selector = kasLabels
-	svc.Spec.Type = corev1.ServiceTypeClusterIP
+	svc.Spec.Selector = kasLabels(instance)
+	switch publishing.Type {
+	case hyperlitev1.NodePortPublishing:
+		svc.Spec.Type = corev1.ServiceTypeNodePort
+		if publishing.NodePort != 0 {
+			svc.Spec.Ports[0].NodePort = publishing.NodePort
+		}
+	case hyperlitev1.LoadBalancerPublishing:
+		svc.Spec.Type = corev1.ServiceTypeLoadBalancer
+	default:
+		svc.Spec.Type = corev1.ServiceTypeClusterIP
+		svc.Spec.Ports[0].NodePort = 0
+	}
 	return nil
 }
@@ -0,0 +1,32 @@
+package kcm
+
+import (
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/ks"
+)
+
+// deniedExtraArgs are controller manager arguments managed by the operator
+// that cannot be overridden with extra arguments.
+var deniedExtraArgs = sets.NewString(
+	"authentication-kubeconfig",
+	"authorization-kubeconfig",
+	"cert-dir",
+	"cluster-cidr",
+	"cluster-signing-cert-file",
+	"cluster-signing-key-file",
+	"feature-gates",
+	"kubeconfig",
+	"openshift-config",
+	"port",
+	"root-ca-file",
+	"secure-port",
+	"service-account-private-key-file",
+	"service-cluster-ip-range",
+)
+
+// ValidateExtraArgs verifies that extra controller manager arguments do not
+// override arguments managed by the operator.
+func ValidateExtraArgs(extraArgs map[string]string) error {
+	return ks.ValidateExtraArgs(extraArgs, deniedExtraArgs)
+}
@@ -3,14 +3,19 @@ package kcm
 import (
 	"fmt"
 	"path"
+	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/pointer"
 
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
 	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/kas"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/ks"
 	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/pki"
 )
 
@@ -37,23 +42,36 @@ const (
 	kcmServiceSignerMountPath = "/etc/kubernetes/certs/service-signer"
 )
 
-var kcmLabels = map[string]string{
-	"app": "kube-controller-manager",
+func kcmLabels(instance string) map[string]string {
+	return map[string]string{
+		"app": ks.Name(instance, "kube-controller-manager"),
+	}
+}
+
+var kcmDefaultResources = corev1.ResourceRequirements{
+	Requests: corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse("100m"),
+		corev1.ResourceMemory: resource.MustParse("300Mi"),
+	},
 }
 
 func ReconcileDeployment(
 	deployment *appsv1.Deployment,
+	instance string,
 	podCIDR string,
 	serviceCIDR string,
 	hyperKubeImage string,
 	replicaCount int,
+	component *hyperlitev1.ComponentSpec,
+	extraArgs map[string]string,
+	featureGates map[string]bool,
 ) error {
 	maxSurge := intstr.FromInt(3)
 	maxUnavailable := intstr.FromInt(1)
 	deployment.Spec = appsv1.DeploymentSpec{
 		Replicas: pointer.Int32Ptr(int32(replicaCount)),
 		Selector: &metav1.LabelSelector{
-			MatchLabels: kcmLabels,
+			MatchLabels: kcmLabels(instance),
 		},
 		Strategy: appsv1.DeploymentStrategy{
 			Type: appsv1.RollingUpdateDeploymentStrategyType,
@@ -64,7 +82,7 @@ func ReconcileDeployment(
 		},
 		Template: corev1.PodTemplateSpec{
 			ObjectMeta: metav1.ObjectMeta{
-				Labels: kcmLabels,
+				Labels: kcmLabels(instance),
 			},
 			Spec: corev1.PodSpec{
 				AutomountServiceAccountToken: pointer.BoolPtr(false),
@@ -76,7 +94,7 @@ func ReconcileDeployment(
 							"hyperkube",
 							"kube-controller-manager",
 						},
-						Args: kcmArgs(podCIDR, serviceCIDR),
+						Args: kcmArgs(podCIDR, serviceCIDR, extraArgs, featureGates),
 						VolumeMounts: []corev1.VolumeMount{
 							{
 								Name:      kcmConfigVolume,
@@ -115,7 +133,7 @@ func ReconcileDeployment(
 						VolumeSource: corev1.VolumeSource{
 							ConfigMap: &corev1.ConfigMapVolumeSource{
 								LocalObjectReference: corev1.LocalObjectReference{
-									Name: Config(deployment.Namespace).Name,
+									Name: Config(deployment.Namespace, instance).Name,
 								},
 							},
 						},
@@ -124,7 +142,7 @@ func ReconcileDeployment(
 						Name: rootCAVolume,
 						VolumeSource: corev1.VolumeSource{
 							Secret: &corev1.SecretVolumeSource{
-								SecretName: pki.RootCASecret(deployment.Namespace).Name,
+								SecretName: pki.RootCASecret(deployment.Namespace, instance).Name,
 							},
 						},
 					},
@@ -138,7 +156,7 @@ func ReconcileDeployment(
 						Name: kubeconfigVolume,
 						VolumeSource: corev1.VolumeSource{
 							Secret: &corev1.SecretVolumeSource{
-								SecretName: kas.ServiceKubeconfigSecret(deployment.Namespace).Name,
+								SecretName: kas.ServiceKubeconfigSecret(deployment.Namespace, instance).Name,
 							},
 						},
 					},
@@ -146,7 +164,7 @@ func ReconcileDeployment(
 						Name: clusterSignerVolume,
 						VolumeSource: corev1.VolumeSource{
 							Secret: &corev1.SecretVolumeSource{
-								SecretName: ClusterSignerSecret(deployment.Namespace).Name,
+								SecretName: ClusterSignerSecret(deployment.Namespace, instance).Name,
 							},
 						},
 					},
@@ -160,7 +178,7 @@ func ReconcileDeployment(
 						Name: serviceSignerVolume,
 						VolumeSource: corev1.VolumeSource{
 							Secret: &corev1.SecretVolumeSource{
-								SecretName: kas.ServiceAccountSigningKeySecret(deployment.Namespace).Name,
+								SecretName: kas.ServiceAccountSigningKeySecret(deployment.Namespace, instance).Name,
 							},
 						},
 					},
@@ -168,10 +186,14 @@ func ReconcileDeployment(
 			},
 		},
 	}
+	if replicaCount > 1 {
+		deployment.Spec.Template.Spec.Affinity = ks.PodAntiAffinity(kcmLabels(instance))
+	}
+	ks.ApplyComponentSpec(&deployment.Spec.Template.Spec, kubeControllerManagerContainer, component, kcmDefaultResources)
 	return nil
 }
 
-func kcmArgs(serviceCIDR, podCIDR string) []string {
+func kcmArgs(podCIDR, serviceCIDR string, extraArgs map[string]string, featureGates map[string]bool) []string {
 	kubeConfigPath := path.Join(kcmKubeconfigMountPath, kas.KubeconfigKey)
 	args := []string{
 		fmt.Sprintf("--openshift-config=%s", path.Join(kcmConfigMountPath, KubeControllerManagerConfigKey)),
@@ -202,8 +224,27 @@ func kcmArgs(serviceCIDR, podCIDR string) []string {
 		"--use-service-account-credentials=true",
 		"--experimental-cluster-signing-duration=26280h",
 	}
-	for _, f := range kas.DefaultFeatureGates {
+	for _, f := range kas.FeatureGates(featureGates) {
 		args = append(args, fmt.Sprintf("--feature-gates=%s", f))
 	}
-	return args
+	return applyExtraArgs(args, extraArgs)
+}
+
+// applyExtraArgs replaces any arguments with the same flag name as an extra
+// argument, and appends the extra arguments in alphabetical order.
+func applyExtraArgs(args []string, extraArgs map[string]string) []string {
+	if len(extraArgs) == 0 {
+		return args
+	}
+	var result []string
+	for _, arg := range args {
+		name := strings.TrimPrefix(strings.SplitN(arg, "=", 2)[0], "--")
+		if _, overridden := extraArgs[name]; !overridden {
+			result = append(result, arg)
+		}
+	}
+	for _, name := range sets.StringKeySet(extraArgs).List() {
+		result = append(result, fmt.Sprintf("--%s=%s", name, extraArgs[name]))
+	}
+	return result
 }
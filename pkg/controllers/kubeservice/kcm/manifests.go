@@ -3,31 +3,43 @@ package kcm
 import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/ks"
 )
 
-func ClusterSignerSecret(ns string) *corev1.Secret {
+func ClusterSignerSecret(ns, instance string) *corev1.Secret {
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "cluster-signer",
+			Name:      ks.Name(instance, "cluster-signer"),
 			Namespace: ns,
 		},
 	}
 }
 
-func Config(ns string) *corev1.ConfigMap {
+func Config(ns, instance string) *corev1.ConfigMap {
 	return &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "kcm-config",
+			Name:      ks.Name(instance, "kcm-config"),
 			Namespace: ns,
 		},
 	}
 }
 
-func Deployment(ns string) *appsv1.Deployment {
+func Deployment(ns, instance string) *appsv1.Deployment {
 	return &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "kube-controller-manager",
+			Name:      ks.Name(instance, "kube-controller-manager"),
+			Namespace: ns,
+		},
+	}
+}
+
+func PodDisruptionBudget(ns, instance string) *policyv1beta1.PodDisruptionBudget {
+	return &policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ks.Name(instance, "kube-controller-manager"),
 			Namespace: ns,
 		},
 	}
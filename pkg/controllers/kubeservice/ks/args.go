@@ -0,0 +1,22 @@
+package ks
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// ValidateExtraArgs verifies that extra arguments of a component are valid
+// flag names that are not in the set of denied arguments.
+func ValidateExtraArgs(extraArgs map[string]string, denied sets.String) error {
+	for _, name := range sets.StringKeySet(extraArgs).List() {
+		if name == "" || strings.HasPrefix(name, "-") || strings.Contains(name, "=") {
+			return fmt.Errorf("invalid argument name %q: names must not include leading dashes or values", name)
+		}
+		if denied.Has(name) {
+			return fmt.Errorf("argument %q is managed by the operator and cannot be set", name)
+		}
+	}
+	return nil
+}
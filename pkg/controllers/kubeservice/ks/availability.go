@@ -0,0 +1,83 @@
+package ks
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
+)
+
+const (
+	hostnameTopologyKey = "kubernetes.io/hostname"
+	zoneTopologyKey     = "topology.kubernetes.io/zone"
+)
+
+// IsHighlyAvailable returns true if the control plane of the KubernetesService
+// should run with multiple replicas per component.
+func IsHighlyAvailable(kubeSvc *hyperlitev1.KubernetesService) bool {
+	return kubeSvc.Spec.Components.AvailabilityPolicy == hyperlitev1.HighlyAvailable
+}
+
+// PodAntiAffinity returns an affinity that requires pods matching the given
+// labels to run on different hosts and prefers that they run in different zones.
+func PodAntiAffinity(labels map[string]string) *corev1.Affinity {
+	selector := &metav1.LabelSelector{
+		MatchLabels: labels,
+	}
+	return &corev1.Affinity{
+		PodAntiAffinity: &corev1.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+				{
+					LabelSelector: selector,
+					TopologyKey:   hostnameTopologyKey,
+				},
+			},
+			PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+				{
+					Weight: 100,
+					PodAffinityTerm: corev1.PodAffinityTerm{
+						LabelSelector: selector,
+						TopologyKey:   zoneTopologyKey,
+					},
+				},
+			},
+		},
+	}
+}
+
+// DeploymentRolledOut returns true if all replicas of a deployment run its
+// latest pod template and are available.
+func DeploymentRolledOut(deployment *appsv1.Deployment) bool {
+	if deployment == nil || deployment.Spec.Replicas == nil {
+		return false
+	}
+	replicas := *deployment.Spec.Replicas
+	return deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.Replicas == replicas &&
+		deployment.Status.UpdatedReplicas == replicas &&
+		deployment.Status.AvailableReplicas == replicas
+}
+
+// DeploymentDegraded returns true if a deployment is serving but has fewer
+// available replicas than desired.
+func DeploymentDegraded(deployment *appsv1.Deployment) bool {
+	if deployment == nil || deployment.Spec.Replicas == nil {
+		return false
+	}
+	return deployment.Status.AvailableReplicas > 0 && deployment.Status.AvailableReplicas < *deployment.Spec.Replicas
+}
+
+// DeploymentRunsImage returns true if a container of the pod template of a
+// deployment runs the given image.
+func DeploymentRunsImage(deployment *appsv1.Deployment, image string) bool {
+	if deployment == nil {
+		return false
+	}
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		if container.Image == image {
+			return true
+		}
+	}
+	return false
+}
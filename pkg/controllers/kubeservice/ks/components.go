@@ -0,0 +1,41 @@
+package ks
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
+)
+
+// ApplyComponentSpec applies the scheduling settings of a component to a pod
+// spec, and its resources to the named container. Default resources are used
+// when the component does not specify any.
+func ApplyComponentSpec(podSpec *corev1.PodSpec, containerName string, component *hyperlitev1.ComponentSpec, defaultResources corev1.ResourceRequirements) {
+	resources := defaultResources
+	if component != nil {
+		if component.Resources != nil {
+			resources = *component.Resources
+		}
+		podSpec.NodeSelector = component.NodeSelector
+		podSpec.Tolerations = component.Tolerations
+		podSpec.TopologySpreadConstraints = component.TopologySpreadConstraints
+		podSpec.PriorityClassName = component.PriorityClassName
+	}
+	for i := range podSpec.Containers {
+		if podSpec.Containers[i].Name == containerName {
+			podSpec.Containers[i].Resources = resources
+		}
+	}
+}
+
+// ValidateComponents verifies that the component settings can be honored.
+func ValidateComponents(components hyperlitev1.ComponentsSpec) error {
+	if len(components.Etcd.TopologySpreadConstraints) > 0 {
+		return fmt.Errorf("topologySpreadConstraints are not supported for etcd")
+	}
+	if components.Etcd.PriorityClassName != "" {
+		return fmt.Errorf("priorityClassName is not supported for etcd")
+	}
+	return nil
+}
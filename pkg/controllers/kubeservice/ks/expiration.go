@@ -0,0 +1,80 @@
+package ks
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
+)
+
+// LifetimeExtensionAnnotation extends the lifetime of a KubernetesService by
+// the given duration. Raising its value extends the lifetime further.
+const LifetimeExtensionAnnotation = "hypershiftlite.openshift.io/lifetime-extension"
+
+// defaultExpiryWarnings are how long before a KubernetesService expires a
+// warning event is emitted when spec.lifecycle.expiryWarnings is not set.
+var defaultExpiryWarnings = []time.Duration{time.Hour, 10 * time.Minute}
+
+// ExpiresAt returns when a KubernetesService expires, including the extension
+// of its lifetime by the LifetimeExtensionAnnotation. It returns false if the
+// KubernetesService does not expire.
+func ExpiresAt(kubeSvc *hyperlitev1.KubernetesService) (time.Time, bool, error) {
+	var expiresAt time.Time
+	switch lifecycle := kubeSvc.Spec.Lifecycle; {
+	case lifecycle.ExpiresAt != nil:
+		expiresAt = lifecycle.ExpiresAt.Time
+	case lifecycle.Lifetime != nil:
+		expiresAt = kubeSvc.CreationTimestamp.Add(lifecycle.Lifetime.Duration)
+	default:
+		return time.Time{}, false, nil
+	}
+	if value, ok := kubeSvc.Annotations[LifetimeExtensionAnnotation]; ok {
+		extension, err := ParseLifetimeExtension(value)
+		if err != nil {
+			return time.Time{}, false, err
+		}
+		expiresAt = expiresAt.Add(extension)
+	}
+	return expiresAt, true, nil
+}
+
+// ExpiryWarnings returns how long before a KubernetesService expires warning
+// events are emitted, longest first.
+func ExpiryWarnings(kubeSvc *hyperlitev1.KubernetesService) []time.Duration {
+	if len(kubeSvc.Spec.Lifecycle.ExpiryWarnings) == 0 {
+		return defaultExpiryWarnings
+	}
+	warnings := make([]time.Duration, 0, len(kubeSvc.Spec.Lifecycle.ExpiryWarnings))
+	for _, warning := range kubeSvc.Spec.Lifecycle.ExpiryWarnings {
+		warnings = append(warnings, warning.Duration)
+	}
+	sort.Slice(warnings, func(i, j int) bool { return warnings[i] > warnings[j] })
+	return warnings
+}
+
+// ParseLifetimeExtension parses the value of the LifetimeExtensionAnnotation,
+// a duration such as "2h".
+func ParseLifetimeExtension(value string) (time.Duration, error) {
+	extension, err := time.ParseDuration(value)
+	if err != nil || extension < 0 {
+		return 0, fmt.Errorf("lifetime extension must be a positive duration such as \"2h\": %q", value)
+	}
+	return extension, nil
+}
+
+// validateExpiration checks the lifetime settings of a KubernetesService.
+func validateExpiration(lifecycle hyperlitev1.LifecycleSpec) error {
+	if lifecycle.Lifetime != nil && lifecycle.ExpiresAt != nil {
+		return fmt.Errorf("lifetime and expiresAt cannot both be set")
+	}
+	if lifecycle.Lifetime != nil && lifecycle.Lifetime.Duration <= 0 {
+		return fmt.Errorf("lifetime must be positive")
+	}
+	for _, warning := range lifecycle.ExpiryWarnings {
+		if warning.Duration <= 0 {
+			return fmt.Errorf("expiryWarnings must be positive")
+		}
+	}
+	return nil
+}
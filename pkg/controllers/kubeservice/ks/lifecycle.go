@@ -0,0 +1,70 @@
+package ks
+
+import (
+	"fmt"
+
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
+)
+
+// DeletionPolicy returns the deletion policy of a KubernetesService, which
+// defaults to Delete.
+func DeletionPolicy(kubeSvc *hyperlitev1.KubernetesService) hyperlitev1.DeletionPolicy {
+	if kubeSvc.Spec.Lifecycle.DeletionPolicy == "" {
+		return hyperlitev1.DeleteDeletionPolicy
+	}
+	return kubeSvc.Spec.Lifecycle.DeletionPolicy
+}
+
+// PowerState returns the power state of a KubernetesService, which defaults to
+// Running.
+func PowerState(kubeSvc *hyperlitev1.KubernetesService) hyperlitev1.PowerState {
+	if kubeSvc.Spec.Lifecycle.PowerState == "" {
+		return hyperlitev1.RunningPowerState
+	}
+	return kubeSvc.Spec.Lifecycle.PowerState
+}
+
+// IsHibernating returns true if the control plane of a KubernetesService is
+// hibernating, hibernated, or restoring etcd as it resumes.
+func IsHibernating(kubeSvc *hyperlitev1.KubernetesService) bool {
+	return PowerState(kubeSvc) == hyperlitev1.HibernatingPowerState || kubeSvc.Status.Hibernation != nil
+}
+
+// ValidateLifecycle checks the lifecycle settings of a KubernetesService.
+func ValidateLifecycle(lifecycle hyperlitev1.LifecycleSpec) error {
+	switch lifecycle.DeletionPolicy {
+	case "", hyperlitev1.DeleteDeletionPolicy, hyperlitev1.OrphanDeletionPolicy:
+	case hyperlitev1.SnapshotDeletionPolicy:
+		if lifecycle.SnapshotStorage == nil {
+			return fmt.Errorf("snapshotStorage is required with the %s deletion policy", hyperlitev1.SnapshotDeletionPolicy)
+		}
+	default:
+		return fmt.Errorf("unsupported deletion policy %s", lifecycle.DeletionPolicy)
+	}
+	switch lifecycle.PowerState {
+	case "", hyperlitev1.RunningPowerState:
+	case hyperlitev1.HibernatingPowerState:
+		if lifecycle.SnapshotStorage == nil {
+			return fmt.Errorf("snapshotStorage is required to hibernate")
+		}
+	default:
+		return fmt.Errorf("unsupported power state %s", lifecycle.PowerState)
+	}
+	if storage := lifecycle.SnapshotStorage; storage != nil {
+		if storage.S3.Bucket == "" {
+			return fmt.Errorf("snapshotStorage.s3.bucket is required")
+		}
+		if storage.S3.Credentials.Name == "" {
+			return fmt.Errorf("snapshotStorage.s3.credentials is required")
+		}
+	}
+	if err := validateExpiration(lifecycle); err != nil {
+		return err
+	}
+	if lifecycle.PausedUntil != "" {
+		if _, _, err := ParsePausedUntil(lifecycle.PausedUntil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
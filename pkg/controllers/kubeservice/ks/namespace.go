@@ -0,0 +1,86 @@
+package ks
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
+)
+
+// Labels referring to the KubernetesService of control plane objects that
+// cannot have it as owner, such as a dedicated control plane namespace and
+// the objects in it.
+const (
+	KubernetesServiceNamespaceLabel = "hypershiftlite.openshift.io/kubernetes-service-namespace"
+	KubernetesServiceNameLabel      = "hypershiftlite.openshift.io/kubernetes-service-name"
+)
+
+// CopiedSecretLabel marks secrets copied from the namespace of a
+// KubernetesService into its dedicated control plane namespace.
+const CopiedSecretLabel = "hypershiftlite.openshift.io/copied-secret"
+
+// ControlPlaneFinalizer is set on KubernetesServices whose control plane must
+// be torn down by the operator when they are deleted.
+const ControlPlaneFinalizer = "hypershiftlite.openshift.io/control-plane"
+
+// IsDedicatedNamespace returns true if the control plane of the
+// KubernetesService runs in a namespace of its own.
+func IsDedicatedNamespace(kubeSvc *hyperlitev1.KubernetesService) bool {
+	return kubeSvc.Spec.ControlPlaneNamespace == hyperlitev1.DedicatedNamespace
+}
+
+// ControlPlaneNamespace returns the namespace the control plane of a
+// KubernetesService runs in.
+func ControlPlaneNamespace(kubeSvc *hyperlitev1.KubernetesService) string {
+	if IsDedicatedNamespace(kubeSvc) {
+		return fmt.Sprintf("%s-%s", kubeSvc.Namespace, kubeSvc.Name)
+	}
+	return kubeSvc.Namespace
+}
+
+// ValidateControlPlaneNamespace ensures the name of a dedicated control plane
+// namespace is a valid namespace name.
+func ValidateControlPlaneNamespace(kubeSvc *hyperlitev1.KubernetesService) error {
+	if !IsDedicatedNamespace(kubeSvc) {
+		return nil
+	}
+	if errs := validation.IsDNS1123Label(ControlPlaneNamespace(kubeSvc)); len(errs) > 0 {
+		return fmt.Errorf("dedicated control plane namespace %s is not a valid namespace name: %v", ControlPlaneNamespace(kubeSvc), errs)
+	}
+	return nil
+}
+
+func Namespace(name string) *corev1.Namespace {
+	return &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	}
+}
+
+// SetKubernetesServiceLabels labels an object with the KubernetesService it
+// belongs to.
+func SetKubernetesServiceLabels(object metav1.Object, kubeSvc *hyperlitev1.KubernetesService) {
+	labels := object.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[KubernetesServiceNamespaceLabel] = kubeSvc.Namespace
+	labels[KubernetesServiceNameLabel] = kubeSvc.Name
+	object.SetLabels(labels)
+}
+
+// KubernetesServiceForLabels returns the KubernetesService an object is
+// labeled with, if any.
+func KubernetesServiceForLabels(object metav1.Object) (types.NamespacedName, bool) {
+	labels := object.GetLabels()
+	namespace, name := labels[KubernetesServiceNamespaceLabel], labels[KubernetesServiceNameLabel]
+	if namespace == "" || name == "" {
+		return types.NamespacedName{}, false
+	}
+	return types.NamespacedName{Namespace: namespace, Name: name}, true
+}
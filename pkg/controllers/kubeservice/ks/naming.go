@@ -0,0 +1,63 @@
+package ks
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
+)
+
+// LegacyNamesAnnotation marks KubernetesServices whose control plane was
+// created before object names were derived from the KubernetesService name.
+// Their objects keep the fixed names they were created with, as renaming the
+// etcd cluster would discard its data.
+const LegacyNamesAnnotation = "hypershiftlite.openshift.io/legacy-names"
+
+// labelNameSuffixes are the suffixes of the control plane objects whose names
+// must be DNS-1035 labels: the services of the API server and etcd, the
+// controller manager, whose name is used as a label value, and the pods of
+// etcd members, which the etcd operator names after the etcd cluster with a
+// random suffix of 10 characters.
+var labelNameSuffixes = []string{
+	"kube-apiserver",
+	"kube-controller-manager",
+	"etcd-client",
+	"etcd-" + strings.Repeat("x", 10),
+}
+
+// InstanceName returns the name the control plane objects of a
+// KubernetesService are prefixed with, or an empty string if they use the
+// legacy fixed names.
+func InstanceName(kubeSvc *hyperlitev1.KubernetesService) string {
+	if kubeSvc.Annotations[LegacyNamesAnnotation] == "true" {
+		return ""
+	}
+	return kubeSvc.Name
+}
+
+// Name returns the name of a control plane object of an instance.
+func Name(instance, name string) string {
+	if instance == "" {
+		return name
+	}
+	return instance + "-" + name
+}
+
+// ValidateInstanceName ensures the names of the control plane objects derived
+// from the name of a KubernetesService are valid. The names of
+// KubernetesServices may contain dots and be longer than the names of services
+// and pods derived from them allow.
+func ValidateInstanceName(kubeSvc *hyperlitev1.KubernetesService) error {
+	instance := InstanceName(kubeSvc)
+	if instance == "" {
+		return nil
+	}
+	for _, suffix := range labelNameSuffixes {
+		if errs := validation.IsDNS1035Label(Name(instance, suffix)); len(errs) > 0 {
+			return fmt.Errorf("%s cannot be used to name control plane objects such as %s: %s", instance, Name(instance, suffix), strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
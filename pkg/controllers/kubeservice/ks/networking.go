@@ -0,0 +1,67 @@
+package ks
+
+import (
+	"fmt"
+	"net"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
+)
+
+const (
+	DefaultServiceCIDR      = "172.30.0.0/16"
+	DefaultPodCIDR          = "10.128.0.0/14"
+	DefaultClusterDomain    = "cluster.local"
+	DefaultAdvertiseAddress = "172.20.0.1"
+)
+
+// Networking returns the networking configuration of a KubernetesService with
+// defaults applied to any field that is not set.
+func Networking(kubeSvc *hyperlitev1.KubernetesService) hyperlitev1.ClusterNetworkSpec {
+	networking := kubeSvc.Spec.Networking.ClusterNetworkSpec
+	if networking.ServiceCIDR == "" {
+		networking.ServiceCIDR = DefaultServiceCIDR
+	}
+	if networking.PodCIDR == "" {
+		networking.PodCIDR = DefaultPodCIDR
+	}
+	if networking.ClusterDomain == "" {
+		networking.ClusterDomain = DefaultClusterDomain
+	}
+	if networking.AdvertiseAddress == "" {
+		networking.AdvertiseAddress = DefaultAdvertiseAddress
+	}
+	return networking
+}
+
+// ValidateNetworking verifies that a defaulted networking configuration is
+// usable by the control plane.
+func ValidateNetworking(networking hyperlitev1.ClusterNetworkSpec) error {
+	_, serviceNet, err := net.ParseCIDR(networking.ServiceCIDR)
+	if err != nil {
+		return fmt.Errorf("invalid service CIDR %q: %w", networking.ServiceCIDR, err)
+	}
+	_, podNet, err := net.ParseCIDR(networking.PodCIDR)
+	if err != nil {
+		return fmt.Errorf("invalid pod CIDR %q: %w", networking.PodCIDR, err)
+	}
+	if cidrsOverlap(serviceNet, podNet) {
+		return fmt.Errorf("service CIDR %s overlaps with pod CIDR %s", networking.ServiceCIDR, networking.PodCIDR)
+	}
+	if errs := validation.IsDNS1123Subdomain(networking.ClusterDomain); len(errs) > 0 {
+		return fmt.Errorf("invalid cluster domain %q: %v", networking.ClusterDomain, errs)
+	}
+	advertiseAddress := net.ParseIP(networking.AdvertiseAddress)
+	if advertiseAddress == nil {
+		return fmt.Errorf("invalid advertise address %q", networking.AdvertiseAddress)
+	}
+	if serviceNet.Contains(advertiseAddress) || podNet.Contains(advertiseAddress) {
+		return fmt.Errorf("advertise address %s must not be within the service or pod CIDR", networking.AdvertiseAddress)
+	}
+	return nil
+}
+
+func cidrsOverlap(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
@@ -0,0 +1,53 @@
+package ks
+
+import (
+	"fmt"
+	"time"
+
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
+)
+
+// PausedUntilAnnotation pauses reconciliation of a KubernetesService like
+// spec.lifecycle.pausedUntil, for use with `oc annotate`.
+const PausedUntilAnnotation = "hypershiftlite.openshift.io/paused-until"
+
+// ReconciliationPaused returns whether reconciliation of a KubernetesService
+// is paused at the given time by spec.lifecycle.pausedUntil or the
+// PausedUntilAnnotation, and when it resumes. The time is zero when it is
+// paused indefinitely.
+func ReconciliationPaused(kubeSvc *hyperlitev1.KubernetesService, now time.Time) (bool, time.Time, error) {
+	var paused bool
+	var resumeAt time.Time
+	for _, value := range []string{kubeSvc.Spec.Lifecycle.PausedUntil, kubeSvc.Annotations[PausedUntilAnnotation]} {
+		if value == "" {
+			continue
+		}
+		indefinitely, until, err := ParsePausedUntil(value)
+		if err != nil {
+			return false, time.Time{}, err
+		}
+		switch {
+		case indefinitely:
+			return true, time.Time{}, nil
+		case until.After(now):
+			paused = true
+			if until.After(resumeAt) {
+				resumeAt = until
+			}
+		}
+	}
+	return paused, resumeAt, nil
+}
+
+// ParsePausedUntil parses a pausedUntil value, which is either "true" to pause
+// indefinitely or an RFC3339 timestamp.
+func ParsePausedUntil(value string) (bool, time.Time, error) {
+	if value == "true" {
+		return true, time.Time{}, nil
+	}
+	until, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("pausedUntil must be \"true\" or an RFC3339 timestamp: %q", value)
+	}
+	return false, until, nil
+}
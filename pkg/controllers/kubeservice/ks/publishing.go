@@ -0,0 +1,54 @@
+package ks
+
+import (
+	"fmt"
+	"net"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
+)
+
+// ValidatePublishing verifies that the API server publishing strategy has the
+// settings it requires.
+func ValidatePublishing(publishing hyperlitev1.APIServerPublishingSpec) error {
+	switch publishing.Type {
+	case hyperlitev1.NodePortPublishing, hyperlitev1.IngressPublishing:
+		if publishing.Hostname == "" {
+			return fmt.Errorf("hostname is required for the %s publishing strategy", publishing.Type)
+		}
+	}
+	if publishing.Hostname != "" && net.ParseIP(publishing.Hostname) == nil {
+		if errs := validation.IsDNS1123Subdomain(publishing.Hostname); len(errs) > 0 {
+			return fmt.Errorf("invalid hostname %q: %v", publishing.Hostname, errs)
+		}
+	}
+	if publishing.NodePort != 0 && publishing.Type != hyperlitev1.NodePortPublishing {
+		return fmt.Errorf("nodePort can only be set with the %s publishing strategy", hyperlitev1.NodePortPublishing)
+	}
+	return nil
+}
+
+// ValidateServingCerts ensures named serving certificates are not served for
+// names that in-cluster clients use to reach the API server.
+func ValidateServingCerts(servingCerts []hyperlitev1.APIServerNamedServingCert, clusterDomain string) error {
+	reserved := sets.NewString(
+		"localhost",
+		"kubernetes",
+		"kubernetes.default",
+		"kubernetes.default.svc",
+		fmt.Sprintf("kubernetes.default.svc.%s", clusterDomain),
+	)
+	for _, namedCert := range servingCerts {
+		if namedCert.ServingCertificate.Name == "" {
+			return fmt.Errorf("serving certificate secret name is required")
+		}
+		for _, name := range namedCert.Names {
+			if reserved.Has(name) {
+				return fmt.Errorf("serving certificate %s cannot be served for in-cluster name %s", namedCert.ServingCertificate.Name, name)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package ks
+
+import (
+	"fmt"
+
+	"github.com/blang/semver"
+
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
+)
+
+// ForceUpgradeAnnotation allows upgrading to the release image it is set to
+// even if the upgrade skips minor versions of Kubernetes, or the Kubernetes
+// versions of the release images are not known.
+const ForceUpgradeAnnotation = "hypershiftlite.openshift.io/force-upgrade"
+
+// kubernetesComponent is the component of a release image whose version is
+// the version of Kubernetes it ships.
+const kubernetesComponent = "kubernetes"
+
+// CurrentRelease returns the most recent release image rolled out completely
+// to the control plane of a KubernetesService, or nil if it is still being
+// installed.
+func CurrentRelease(kubeSvc *hyperlitev1.KubernetesService) *hyperlitev1.ReleaseHistory {
+	for i := range kubeSvc.Status.History {
+		if kubeSvc.Status.History[i].State == hyperlitev1.CompletedUpgradeState {
+			return &kubeSvc.Status.History[i]
+		}
+	}
+	return nil
+}
+
+// IsUpgrading returns true if the control plane of a KubernetesService is
+// being upgraded or rolled back to another release image.
+func IsUpgrading(kubeSvc *hyperlitev1.KubernetesService) bool {
+	return kubeSvc.Status.Upgrade != nil
+}
+
+// UpgradeForced returns true if the ForceUpgradeAnnotation of a
+// KubernetesService allows upgrading to the given release image.
+func UpgradeForced(kubeSvc *hyperlitev1.KubernetesService, image string) bool {
+	return image != "" && kubeSvc.Annotations[ForceUpgradeAnnotation] == image
+}
+
+// ValidateUpgrade checks an upgrade between release images with the given
+// component versions against the version skew policy of Kubernetes: the
+// Kubernetes version cannot be downgraded, and is upgraded by at most one
+// minor version unless the upgrade is forced. Upgrades between release images
+// whose Kubernetes versions are not known are only allowed when forced. It
+// returns the reason the upgrade is not supported along with the error.
+func ValidateUpgrade(current, target map[string]string, force bool) (string, error) {
+	currentVersion, currentKnown := current[kubernetesComponent]
+	targetVersion, targetKnown := target[kubernetesComponent]
+	if !currentKnown || !targetKnown {
+		if force {
+			return "", nil
+		}
+		release := "current"
+		if currentKnown {
+			release = "target"
+		}
+		return "UnknownVersion", fmt.Errorf("the kubernetes version of the %s release is not known, set the %s annotation to the release image to upgrade anyway", release, ForceUpgradeAnnotation)
+	}
+	from, err := semver.ParseTolerant(currentVersion)
+	if err != nil {
+		return "InvalidVersion", fmt.Errorf("invalid kubernetes version %q of the current release: %w", currentVersion, err)
+	}
+	to, err := semver.ParseTolerant(targetVersion)
+	if err != nil {
+		return "InvalidVersion", fmt.Errorf("invalid kubernetes version %q of the target release: %w", targetVersion, err)
+	}
+	switch {
+	case to.LT(from):
+		return "Downgrade", fmt.Errorf("downgrading kubernetes from %s to %s is not supported", from, to)
+	case force:
+		return "", nil
+	case to.Major != from.Major || to.Minor > from.Minor+1:
+		return "UnsupportedVersionSkew", fmt.Errorf("upgrading kubernetes from %s to %s skips minor versions, upgrade through each minor version or set the %s annotation to the release image", from, to, ForceUpgradeAnnotation)
+	}
+	return "", nil
+}
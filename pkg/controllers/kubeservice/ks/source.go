@@ -0,0 +1,49 @@
+package ks
+
+import (
+	"fmt"
+
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
+)
+
+// RestoringSource returns true if the etcd cluster of a KubernetesService is
+// still to be restored from its source, in which case it must not be created
+// by the regular reconciliation.
+func RestoringSource(kubeSvc *hyperlitev1.KubernetesService) bool {
+	return kubeSvc.Spec.Source != nil && (kubeSvc.Status.Source == nil || kubeSvc.Status.Source.RestoreTime == nil)
+}
+
+// SourceCompleted returns true if a KubernetesService has no source or its
+// control plane has been made independent of its source.
+func SourceCompleted(kubeSvc *hyperlitev1.KubernetesService) bool {
+	return kubeSvc.Spec.Source == nil || (kubeSvc.Status.Source != nil && kubeSvc.Status.Source.CompletionTime != nil)
+}
+
+// ValidateSource checks the source of a KubernetesService.
+func ValidateSource(kubeSvc *hyperlitev1.KubernetesService) error {
+	source := kubeSvc.Spec.Source
+	if source == nil {
+		return nil
+	}
+	switch {
+	case source.FromKubernetesService != nil && source.FromSnapshot != nil:
+		return fmt.Errorf("only one of fromKubernetesService and fromSnapshot may be set")
+	case source.FromKubernetesService != nil:
+		if source.FromKubernetesService.Name == "" {
+			return fmt.Errorf("fromKubernetesService.name is required")
+		}
+		if source.FromKubernetesService.Name == kubeSvc.Name {
+			return fmt.Errorf("a KubernetesService cannot be created from itself")
+		}
+	case source.FromSnapshot != nil:
+		if source.FromSnapshot.Path == "" {
+			return fmt.Errorf("fromSnapshot.path is required")
+		}
+	default:
+		return fmt.Errorf("one of fromKubernetesService and fromSnapshot is required")
+	}
+	if kubeSvc.Spec.Lifecycle.SnapshotStorage == nil {
+		return fmt.Errorf("lifecycle.snapshotStorage is required to create a KubernetesService from a source")
+	}
+	return nil
+}
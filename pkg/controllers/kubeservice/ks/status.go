@@ -5,7 +5,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1alpha1"
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
 )
 
 func SetConditionByType(conditions *[]hyperlitev1.KubernetesServiceCondition, conditionType hyperlitev1.ConditionType, status corev1.ConditionStatus, reason, message string) {
@@ -0,0 +1,212 @@
+package kubeservice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/etcd"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/kas"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/kcm"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/ks"
+	etcdv1 "github.com/openshift-hive/hypershiftlite/thirdparty/etcd/v1beta2"
+)
+
+const (
+	// snapshotTimeFormat is the format of the time in the names of snapshots.
+	snapshotTimeFormat = "20060102T150405Z"
+
+	// restorePollInterval is how often the progress of an etcd restore is
+	// checked.
+	restorePollInterval = 5 * time.Second
+)
+
+// stopAPIServer stops the controller manager and then the API server, so that
+// the controller manager does not act on a disappearing API and etcd receives
+// no more writes. It returns true once both are stopped, reporting progress in
+// the given condition until then.
+func (r *KubernetesServiceReconciler) stopAPIServer(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService, conditionType hyperlitev1.ConditionType) (bool, error) {
+	namespace, instance := ks.ControlPlaneNamespace(kubeSvc), ks.InstanceName(kubeSvc)
+	if stopped, err := r.scaleDownDeployment(ctx, kcm.Deployment(namespace, instance)); err != nil || !stopped {
+		if err != nil {
+			return false, err
+		}
+		return false, r.setProgressCondition(ctx, kubeSvc, conditionType, "StoppingKubeControllerManager", "Waiting for the kube controller manager to stop")
+	}
+	if stopped, err := r.scaleDownDeployment(ctx, kas.Deployment(namespace, instance)); err != nil || !stopped {
+		if err != nil {
+			return false, err
+		}
+		return false, r.setProgressCondition(ctx, kubeSvc, conditionType, "StoppingKubeAPIServer", "Waiting for the kube API server to stop")
+	}
+	return true, nil
+}
+
+// scaleDownDeployment scales a control plane deployment to zero replicas and
+// returns true once none of its pods are left.
+func (r *KubernetesServiceReconciler) scaleDownDeployment(ctx context.Context, deployment *appsv1.Deployment) (bool, error) {
+	if err := r.Get(ctx, client.ObjectKeyFromObject(deployment), deployment); err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("cannot get deployment %s: %w", deployment.Name, err)
+	}
+	if deployment.Spec.Replicas == nil || *deployment.Spec.Replicas != 0 {
+		ctrl.LoggerFrom(ctx).Info("Scaling down deployment", "name", deployment.Name)
+		var replicas int32
+		deployment.Spec.Replicas = &replicas
+		if err := r.Update(ctx, deployment); err != nil {
+			return false, fmt.Errorf("failed to scale down deployment %s: %w", deployment.Name, err)
+		}
+	}
+	return deployment.Status.ObservedGeneration >= deployment.Generation && deployment.Status.Replicas == 0, nil
+}
+
+// reconcileSnapshot saves a snapshot of etcd to the given path of the snapshot
+// storage of the KubernetesService and returns true once it has been saved,
+// reporting progress in the given condition until then. A failed snapshot is
+// attempted again.
+func (r *KubernetesServiceReconciler) reconcileSnapshot(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService, snapshot, snapshotPath string, conditionType hyperlitev1.ConditionType) (bool, error) {
+	backup := etcd.Backup(ks.ControlPlaneNamespace(kubeSvc), ks.InstanceName(kubeSvc), snapshot)
+	return r.reconcileBackup(ctx, kubeSvc, backup, ks.InstanceName(kubeSvc), kubeSvc.Spec.Lifecycle.SnapshotStorage, snapshotPath, conditionType)
+}
+
+// reconcileBackup saves a snapshot of the etcd cluster of an instance in the
+// namespace of the backup to the given path of the snapshot storage, on
+// behalf of the KubernetesService. It returns true once it has been saved.
+func (r *KubernetesServiceReconciler) reconcileBackup(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService, backup *etcdv1.EtcdBackup, instance string, storage *hyperlitev1.SnapshotStorageSpec, snapshotPath string, conditionType hyperlitev1.ConditionType) (bool, error) {
+	if err := r.Get(ctx, client.ObjectKeyFromObject(backup), backup); err != nil && !apierrors.IsNotFound(err) {
+		return false, fmt.Errorf("cannot get etcd backup: %w", err)
+	} else if apierrors.IsNotFound(err) {
+		ctrl.LoggerFrom(ctx).Info("Saving etcd snapshot", "path", snapshotPath)
+		ensureKSOwnerRef(kubeSvc, backup)
+		if err := etcd.ReconcileBackup(backup, instance, storage, snapshotPath); err != nil {
+			return false, err
+		}
+		if err := r.Create(ctx, backup); err != nil {
+			return false, fmt.Errorf("failed to create etcd backup: %w", err)
+		}
+		return false, r.setProgressCondition(ctx, kubeSvc, conditionType, "SavingSnapshot", fmt.Sprintf("Saving etcd snapshot to %s", snapshotPath))
+	}
+
+	// A backup left over from an earlier snapshot is replaced
+	if backup.Spec.S3 == nil || backup.Spec.S3.Path != snapshotPath {
+		return false, r.deleteIfExists(ctx, backup)
+	}
+	if backup.Status.Succeeded {
+		return true, nil
+	}
+	if reason := etcd.BackupFailed(backup); reason != "" {
+		// Delete the failed backup so that it is attempted again
+		r.recorder.Eventf(kubeSvc, corev1.EventTypeWarning, "SnapshotFailed", "Failed to save etcd snapshot to %s: %s", snapshotPath, reason)
+		if err := r.deleteIfExists(ctx, backup); err != nil {
+			return false, fmt.Errorf("failed to delete failed etcd backup: %w", err)
+		}
+		return false, r.setProgressCondition(ctx, kubeSvc, conditionType, "SnapshotFailed", fmt.Sprintf("Failed to save etcd snapshot, retrying: %s", reason))
+	}
+	return false, r.setProgressCondition(ctx, kubeSvc, conditionType, "SavingSnapshot", fmt.Sprintf("Saving etcd snapshot to %s", snapshotPath))
+}
+
+// reconcileRestore replaces the etcd cluster of the KubernetesService with one
+// restored from the snapshot at the given path of its snapshot storage. It
+// returns true once etcd has been restored, reporting progress in the given
+// condition until then. A failed restore is attempted again. The etcd
+// operator must be running.
+func (r *KubernetesServiceReconciler) reconcileRestore(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService, snapshot, snapshotPath string, conditionType hyperlitev1.ConditionType) (bool, error) {
+	instance := ks.InstanceName(kubeSvc)
+	restore := etcd.Restore(ks.ControlPlaneNamespace(kubeSvc), instance, snapshot)
+	if err := r.Get(ctx, client.ObjectKeyFromObject(restore), restore); err != nil && !apierrors.IsNotFound(err) {
+		return false, fmt.Errorf("cannot get etcd restore: %w", err)
+	} else if apierrors.IsNotFound(err) {
+		if err := r.ensurePausedEtcdCluster(ctx, kubeSvc); err != nil {
+			return false, err
+		}
+		ensureKSOwnerRef(kubeSvc, restore)
+		if err := etcd.ReconcileRestore(restore, instance, kubeSvc.Spec.Lifecycle.SnapshotStorage, snapshotPath); err != nil {
+			return false, err
+		}
+		if err := r.Create(ctx, restore); err != nil {
+			return false, fmt.Errorf("failed to create etcd restore: %w", err)
+		}
+		return false, r.setProgressCondition(ctx, kubeSvc, conditionType, "RestoringEtcd", fmt.Sprintf("Restoring etcd from %s", snapshotPath))
+	}
+	if reason := restore.Status.Reason; !restore.Status.Succeeded && reason != "" {
+		// Delete the failed restore so that it is attempted again
+		r.recorder.Eventf(kubeSvc, corev1.EventTypeWarning, "RestoreFailed", "Failed to restore etcd from %s: %s", snapshotPath, reason)
+		if err := r.deleteIfExists(ctx, restore); err != nil {
+			return false, fmt.Errorf("failed to delete failed etcd restore: %w", err)
+		}
+		return false, r.setProgressCondition(ctx, kubeSvc, conditionType, "RestoreFailed", fmt.Sprintf("Failed to restore etcd, retrying: %s", reason))
+	}
+	if !restore.Status.Succeeded {
+		return false, r.setProgressCondition(ctx, kubeSvc, conditionType, "RestoringEtcd", fmt.Sprintf("Restoring etcd from %s", snapshotPath))
+	}
+	r.recorder.Eventf(kubeSvc, corev1.EventTypeNormal, "EtcdRestored", "Restored etcd from %s", snapshotPath)
+	if err := r.deleteIfExists(ctx, restore); err != nil {
+		return false, fmt.Errorf("failed to delete etcd restore: %w", err)
+	}
+	return true, nil
+}
+
+// ensurePausedEtcdCluster creates the etcd cluster of a KubernetesService if
+// it does not exist, paused so that it has no members until it is replaced by
+// a restored cluster.
+func (r *KubernetesServiceReconciler) ensurePausedEtcdCluster(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService) error {
+	etcdCluster := etcd.Cluster(ks.ControlPlaneNamespace(kubeSvc), ks.InstanceName(kubeSvc))
+	if err := r.Get(ctx, client.ObjectKeyFromObject(etcdCluster), etcdCluster); err == nil || !apierrors.IsNotFound(err) {
+		return err
+	}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r, etcdCluster, func() error {
+		ensureKSOwnerRef(kubeSvc, etcdCluster)
+		if err := etcd.ReconcileCluster(etcdCluster, ks.InstanceName(kubeSvc), replicasFor(kubeSvc, etcdClusterReplicas, etcdClusterHAReplicas), etcdVersion, &kubeSvc.Spec.Components.Etcd); err != nil {
+			return err
+		}
+		etcdCluster.Spec.Paused = true
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to create etcd cluster: %w", err)
+	}
+	return nil
+}
+
+// waitForEtcdOperator starts the etcd operator again if it was stopped, and
+// returns true once it is available. The etcd restore operator runs in the etcd
+// operator deployment. Progress is reported in the given condition until then.
+func (r *KubernetesServiceReconciler) waitForEtcdOperator(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService, conditionType hyperlitev1.ConditionType) (bool, error) {
+	operatorDeployment := etcd.OperatorDeployment(ks.ControlPlaneNamespace(kubeSvc), ks.InstanceName(kubeSvc))
+	if err := r.Get(ctx, client.ObjectKeyFromObject(operatorDeployment), operatorDeployment); err != nil {
+		return false, fmt.Errorf("cannot get etcd operator deployment: %w", err)
+	}
+	if operatorDeployment.Spec.Replicas != nil && *operatorDeployment.Spec.Replicas == 0 {
+		operatorDeployment.Spec.Replicas = nil
+		if err := r.Update(ctx, operatorDeployment); err != nil {
+			return false, fmt.Errorf("failed to scale up etcd operator deployment: %w", err)
+		}
+	}
+	if operatorDeployment.Status.AvailableReplicas == 0 {
+		return false, r.setProgressCondition(ctx, kubeSvc, conditionType, "StartingEtcdOperator", "Waiting for the etcd operator to start")
+	}
+	return true, nil
+}
+
+// setProgressCondition reports the progress of a lifecycle operation of the
+// control plane, such as its teardown, in the given condition.
+func (r *KubernetesServiceReconciler) setProgressCondition(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService, conditionType hyperlitev1.ConditionType, reason, message string) error {
+	if condition := ks.GetConditionByType(kubeSvc.Status.Conditions, conditionType); condition != nil &&
+		condition.Status == corev1.ConditionTrue && condition.Reason == reason && condition.Message == message {
+		return nil
+	}
+	ks.SetConditionByType(&kubeSvc.Status.Conditions, conditionType, corev1.ConditionTrue, reason, message)
+	if err := r.Status().Update(ctx, kubeSvc); err != nil {
+		return fmt.Errorf("failed to update kubernetes service status: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,228 @@
+package kubeservice
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/kas"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/ks"
+)
+
+// reconcileControlPlaneNamespace creates the dedicated namespace of a
+// KubernetesService and copies the secrets its control plane uses from the
+// namespace of the KubernetesService into it.
+func (r *KubernetesServiceReconciler) reconcileControlPlaneNamespace(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService) error {
+	if !ks.IsDedicatedNamespace(kubeSvc) {
+		return nil
+	}
+
+	namespace := ks.Namespace(ks.ControlPlaneNamespace(kubeSvc))
+	if _, err := controllerutil.CreateOrUpdate(ctx, r, namespace, func() error {
+		ensureKSOwnerRef(kubeSvc, namespace)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile control plane namespace: %w", err)
+	}
+
+	referenced := referencedSecrets(kubeSvc)
+	for _, name := range referenced.List() {
+		source := &corev1.Secret{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: kubeSvc.Namespace, Name: name}, source); err != nil {
+			return fmt.Errorf("cannot get secret %s: %w", name, err)
+		}
+		secret := &corev1.Secret{}
+		secret.Namespace = namespace.Name
+		secret.Name = name
+		if _, err := controllerutil.CreateOrUpdate(ctx, r, secret, func() error {
+			if secret.ResourceVersion != "" && secret.Labels[ks.CopiedSecretLabel] != "true" {
+				return fmt.Errorf("secret %s already exists in the control plane namespace and was not copied from namespace %s", name, kubeSvc.Namespace)
+			}
+			ensureKSOwnerRef(kubeSvc, secret)
+			secret.Labels[ks.CopiedSecretLabel] = "true"
+			secret.Type = source.Type
+			secret.Data = source.Data
+			return nil
+		}); err != nil {
+			return fmt.Errorf("failed to copy secret %s to the control plane namespace: %w", name, err)
+		}
+	}
+
+	copies := &corev1.SecretList{}
+	if err := r.List(ctx, copies, client.InNamespace(namespace.Name), client.MatchingLabels{ks.CopiedSecretLabel: "true"}); err != nil {
+		return fmt.Errorf("failed to list secrets copied to the control plane namespace: %w", err)
+	}
+	for i := range copies.Items {
+		if referenced.Has(copies.Items[i].Name) {
+			continue
+		}
+		if err := r.Delete(ctx, &copies.Items[i]); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to remove secret %s from the control plane namespace: %w", copies.Items[i].Name, err)
+		}
+	}
+	return nil
+}
+
+// referencedSecrets returns the names of the secrets in the namespace of a
+// KubernetesService that its control plane uses.
+func referencedSecrets(kubeSvc *hyperlitev1.KubernetesService) sets.String {
+	names := sets.NewString(kubeSvc.Spec.Release.PullSecret.Name)
+	for _, namedCert := range kubeSvc.Spec.Components.KubeAPIServer.ServingCerts {
+		names.Insert(namedCert.ServingCertificate.Name)
+	}
+	authentication := kubeSvc.Spec.Security.Authentication
+	if authentication.OIDC != nil && authentication.OIDC.CA != nil {
+		names.Insert(authentication.OIDC.CA.Name)
+	}
+	if authentication.Webhook != nil {
+		names.Insert(authentication.Webhook.KubeConfig.Name)
+	}
+	if webhook := kubeSvc.Spec.Security.Authorization.Webhook; webhook != nil {
+		names.Insert(webhook.KubeConfig.Name)
+	}
+	if kms := kmsPlugin(kubeSvc); kms != nil && kms.Credentials != nil {
+		names.Insert(kms.Credentials.Name)
+	}
+	if storage := kubeSvc.Spec.Lifecycle.SnapshotStorage; storage != nil {
+		names.Insert(storage.S3.Credentials.Name)
+	}
+	names.Delete("")
+	return names
+}
+
+// validateReferencedSecrets ensures none of the secrets copied to the dedicated
+// namespace of a KubernetesService would take the place of a secret of its
+// control plane.
+func validateReferencedSecrets(kubeSvc *hyperlitev1.KubernetesService) error {
+	if !ks.IsDedicatedNamespace(kubeSvc) {
+		return nil
+	}
+	referenced := referencedSecrets(kubeSvc)
+	for _, object := range controlPlaneObjects(ks.ControlPlaneNamespace(kubeSvc), ks.InstanceName(kubeSvc)) {
+		if _, isSecret := object.(*corev1.Secret); isSecret && referenced.Has(object.GetName()) {
+			return fmt.Errorf("secret %s cannot be used as it has the name of a secret of the control plane", object.GetName())
+		}
+	}
+	return nil
+}
+
+// reconcileExportedKubeconfigs makes the kubeconfigs of a control plane running
+// in a dedicated namespace available in the namespace of the KubernetesService.
+// Only kubeconfigs that work from outside of the control plane namespace are
+// exported. It returns the exported service and external kubeconfig secrets.
+func (r *KubernetesServiceReconciler) reconcileExportedKubeconfigs(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService, rootCASecret *corev1.Secret, kubeAPIServerService *corev1.Service, externalKubeconfigSecret *corev1.Secret, published bool) (*corev1.Secret, *corev1.Secret, error) {
+	instance := ks.InstanceName(kubeSvc)
+
+	serviceKubeconfigSecret := kas.ServiceKubeconfigSecret(kubeSvc.Namespace, instance)
+	if _, err := controllerutil.CreateOrUpdate(ctx, r, serviceKubeconfigSecret, func() error {
+		ensureKSOwnerRef(kubeSvc, serviceKubeconfigSecret)
+		return kas.ReconcileExportedServiceKubeconfigSecret(serviceKubeconfigSecret, rootCASecret, kubeAPIServerService, kubeAPIServerPort)
+	}); err != nil {
+		return nil, nil, fmt.Errorf("failed to reconcile exported service kubeconfig secret: %w", err)
+	}
+
+	exportedExternalKubeconfigSecret := kas.ExternalKubeconfigSecret(kubeSvc.Namespace, instance)
+	if published {
+		if _, err := controllerutil.CreateOrUpdate(ctx, r, exportedExternalKubeconfigSecret, func() error {
+			ensureKSOwnerRef(kubeSvc, exportedExternalKubeconfigSecret)
+			exportedExternalKubeconfigSecret.Type = externalKubeconfigSecret.Type
+			exportedExternalKubeconfigSecret.Data = externalKubeconfigSecret.Data
+			return nil
+		}); err != nil {
+			return nil, nil, fmt.Errorf("failed to reconcile exported external kubeconfig secret: %w", err)
+		}
+	} else if err := r.deleteIfExists(ctx, exportedExternalKubeconfigSecret); err != nil {
+		return nil, nil, fmt.Errorf("failed to remove exported external kubeconfig secret: %w", err)
+	}
+	return serviceKubeconfigSecret, exportedExternalKubeconfigSecret, nil
+}
+
+// deleteControlPlaneNamespace deletes the dedicated namespace of a
+// KubernetesService along with everything left in it. It returns true once the
+// namespace is gone.
+func (r *KubernetesServiceReconciler) deleteControlPlaneNamespace(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService) (bool, error) {
+	namespace := ks.Namespace(ks.ControlPlaneNamespace(kubeSvc))
+	if err := r.Get(ctx, client.ObjectKeyFromObject(namespace), namespace); err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("cannot get control plane namespace: %w", err)
+	}
+	if !ownsNamespace(kubeSvc, namespace) {
+		return true, nil
+	}
+	if namespace.DeletionTimestamp.IsZero() {
+		ctrl.LoggerFrom(ctx).Info("Deleting control plane namespace", "namespace", namespace.Name)
+		if err := r.Delete(ctx, namespace); err != nil && !apierrors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to delete control plane namespace: %w", err)
+		}
+	}
+	return false, nil
+}
+
+// validateControlPlaneNamespace ensures the control plane namespace has not
+// changed since the control plane was created, and that a dedicated namespace
+// does not belong to another KubernetesService.
+func (r *KubernetesServiceReconciler) validateControlPlaneNamespace(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService) error {
+	if err := ks.ValidateControlPlaneNamespace(kubeSvc); err != nil {
+		return err
+	}
+	if applied := kubeSvc.Status.ControlPlaneNamespace; applied != "" && applied != ks.ControlPlaneNamespace(kubeSvc) {
+		return fmt.Errorf("controlPlaneNamespace cannot be changed after creation: the control plane runs in namespace %s", applied)
+	}
+	if !ks.IsDedicatedNamespace(kubeSvc) {
+		return nil
+	}
+	namespace := ks.Namespace(ks.ControlPlaneNamespace(kubeSvc))
+	if err := r.Get(ctx, client.ObjectKeyFromObject(namespace), namespace); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("cannot get control plane namespace: %w", err)
+	}
+	if !ownsNamespace(kubeSvc, namespace) {
+		return fmt.Errorf("namespace %s already exists and does not belong to this kubernetes service", namespace.Name)
+	}
+	return nil
+}
+
+// ownsNamespace returns true if the namespace was created as the dedicated
+// control plane namespace of the KubernetesService.
+func ownsNamespace(kubeSvc *hyperlitev1.KubernetesService, namespace *corev1.Namespace) bool {
+	owner, ok := ks.KubernetesServiceForLabels(namespace)
+	return ok && owner == client.ObjectKeyFromObject(kubeSvc)
+}
+
+// kubeServiceForLabels maps a control plane object in a dedicated namespace to
+// the KubernetesService it is labeled with.
+func (r *KubernetesServiceReconciler) kubeServiceForLabels(obj client.Object) []reconcile.Request {
+	if kubeSvc, ok := ks.KubernetesServiceForLabels(obj); ok {
+		return []reconcile.Request{{NamespacedName: kubeSvc}}
+	}
+	return nil
+}
+
+// kubeServicesForSecret maps a secret to the KubernetesServices with a
+// dedicated control plane namespace that use it, so that its copy is updated.
+func (r *KubernetesServiceReconciler) kubeServicesForSecret(obj client.Object) []reconcile.Request {
+	kubeServices := &hyperlitev1.KubernetesServiceList{}
+	if err := r.List(context.Background(), kubeServices, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, kubeSvc := range kubeServices.Items {
+		if ks.IsDedicatedNamespace(&kubeSvc) && referencedSecrets(&kubeSvc).Has(obj.GetName()) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&kubeSvc)})
+		}
+	}
+	return requests
+}
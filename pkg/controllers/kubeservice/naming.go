@@ -0,0 +1,152 @@
+package kubeservice
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/etcd"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/kas"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/kcm"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/ks"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/pki"
+)
+
+// controlPlaneObjects returns the objects making up the control plane of an
+// instance.
+func controlPlaneObjects(namespace, instance string) []client.Object {
+	return []client.Object{
+		pki.RootCASecret(namespace, instance),
+		etcd.ClientSecret(namespace, instance),
+		etcd.ServerSecret(namespace, instance),
+		etcd.PeerSecret(namespace, instance),
+		etcd.OperatorServiceAccount(namespace, instance),
+		etcd.OperatorRole(namespace, instance),
+		etcd.OperatorRoleBinding(namespace, instance),
+		etcd.OperatorDeployment(namespace, instance),
+		etcd.Cluster(namespace, instance),
+		etcd.PodDisruptionBudget(namespace, instance),
+		kas.Service(namespace, instance),
+		kas.Route(namespace, instance),
+		kas.Ingress(namespace, instance),
+		kas.ServerCertSecret(namespace, instance),
+		kas.AggregatorCertSecret(namespace, instance),
+		kas.ServiceAccountSigningKeySecret(namespace, instance),
+		kas.ServiceKubeconfigSecret(namespace, instance),
+		kas.LocalhostKubeconfigSecret(namespace, instance),
+		kas.ExternalKubeconfigSecret(namespace, instance),
+		kas.EncryptionConfigSecret(namespace, instance),
+		kas.AuditConfig(namespace, instance),
+		kas.Config(namespace, instance),
+		kas.OAuthMetadata(namespace, instance),
+		kas.Deployment(namespace, instance),
+		kas.PodDisruptionBudget(namespace, instance),
+		kcm.ClusterSignerSecret(namespace, instance),
+		kcm.Config(namespace, instance),
+		kcm.Deployment(namespace, instance),
+		kcm.PodDisruptionBudget(namespace, instance),
+	}
+}
+
+// reconcileLegacyNames decides whether a KubernetesService keeps the fixed
+// object names used before names were derived from the KubernetesService name.
+// The KubernetesService that created the legacy objects keeps them. Any other
+// KubernetesService sharing them is removed from their owners and gets a
+// control plane of its own.
+func (r *KubernetesServiceReconciler) reconcileLegacyNames(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService) error {
+	if kubeSvc.Annotations[ks.LegacyNamesAnnotation] == "true" || ks.IsDedicatedNamespace(kubeSvc) {
+		return nil
+	}
+	log := ctrl.LoggerFrom(ctx)
+	rootCASecret := pki.RootCASecret(kubeSvc.Namespace, "")
+	if err := r.Get(ctx, client.ObjectKeyFromObject(rootCASecret), rootCASecret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("cannot get legacy root CA secret: %w", err)
+	}
+
+	if owner := kubeServiceOwner(rootCASecret); owner != nil && owner.UID == kubeSvc.UID {
+		log.Info("Keeping legacy names of control plane objects")
+		if kubeSvc.Annotations == nil {
+			kubeSvc.Annotations = map[string]string{}
+		}
+		kubeSvc.Annotations[ks.LegacyNamesAnnotation] = "true"
+		if err := r.Update(ctx, kubeSvc); err != nil {
+			return fmt.Errorf("failed to annotate kubernetes service with legacy names: %w", err)
+		}
+		return nil
+	}
+
+	for _, object := range controlPlaneObjects(kubeSvc.Namespace, "") {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(object), object); err != nil {
+			if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+				continue
+			}
+			return fmt.Errorf("cannot get legacy object %s: %w", object.GetName(), err)
+		}
+		ownerRefs := removeKSOwnerRef(kubeSvc, object.GetOwnerReferences())
+		if len(ownerRefs) == len(object.GetOwnerReferences()) {
+			continue
+		}
+		log.Info("Releasing legacy object owned by another kubernetes service", "name", object.GetName())
+		object.SetOwnerReferences(ownerRefs)
+		if err := r.Update(ctx, object); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to release legacy object %s: %w", object.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// validateObjectOwnership ensures none of the control plane objects of a
+// KubernetesService were created for another KubernetesService, which happens
+// when their names collide.
+func (r *KubernetesServiceReconciler) validateObjectOwnership(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService) error {
+	for _, object := range controlPlaneObjects(ks.ControlPlaneNamespace(kubeSvc), ks.InstanceName(kubeSvc)) {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(object), object); err != nil {
+			if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+				continue
+			}
+			return fmt.Errorf("cannot get %s: %w", object.GetName(), err)
+		}
+		if owner := kubeServiceOwner(object); owner != nil && owner.UID != kubeSvc.UID {
+			kind := "object"
+			if gvk, err := apiutil.GVKForObject(object, r.Scheme()); err == nil {
+				kind = gvk.Kind
+			}
+			return fmt.Errorf("%s %s already belongs to kubernetes service %s", kind, object.GetName(), owner.Name)
+		}
+	}
+	return nil
+}
+
+// kubeServiceOwner returns the first KubernetesService owner reference of an
+// object, which refers to the KubernetesService that created it.
+func kubeServiceOwner(object client.Object) *metav1.OwnerReference {
+	for _, ref := range object.GetOwnerReferences() {
+		if ref.Kind == "KubernetesService" {
+			return &ref
+		}
+	}
+	return nil
+}
+
+// removeKSOwnerRef returns the owner references without the ones referring to
+// the KubernetesService.
+func removeKSOwnerRef(kubeSvc *hyperlitev1.KubernetesService, ownerReferences []metav1.OwnerReference) []metav1.OwnerReference {
+	var refs []metav1.OwnerReference
+	for _, ref := range ownerReferences {
+		if ref.Kind == "KubernetesService" && ref.UID == kubeSvc.UID {
+			continue
+		}
+		refs = append(refs, ref)
+	}
+	return refs
+}
@@ -0,0 +1,32 @@
+package kubeservice
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/ks"
+)
+
+// reconcilePausedCondition reports whether reconciliation of a
+// KubernetesService is paused in the ReconciliationPaused condition. It
+// returns whether it is paused and, if it is paused until a point in time, how
+// long until it resumes. An invalid pausedUntil value does not pause
+// reconciliation.
+func (r *KubernetesServiceReconciler) reconcilePausedCondition(kubeSvc *hyperlitev1.KubernetesService) (bool, time.Duration) {
+	paused, resumeAt, err := ks.ReconciliationPaused(kubeSvc, time.Now())
+	switch {
+	case err != nil:
+		ks.SetConditionByType(&kubeSvc.Status.Conditions, hyperlitev1.ReconciliationPaused, corev1.ConditionFalse, "InvalidPausedUntil", err.Error())
+	case paused && resumeAt.IsZero():
+		ks.SetConditionByType(&kubeSvc.Status.Conditions, hyperlitev1.ReconciliationPaused, corev1.ConditionTrue, "Paused", "Reconciliation is paused until pausedUntil is removed")
+	case paused:
+		ks.SetConditionByType(&kubeSvc.Status.Conditions, hyperlitev1.ReconciliationPaused, corev1.ConditionTrue, "Paused", fmt.Sprintf("Reconciliation is paused until %s", resumeAt.UTC().Format(time.RFC3339)))
+		return true, time.Until(resumeAt)
+	default:
+		ks.SetConditionByType(&kubeSvc.Status.Conditions, hyperlitev1.ReconciliationPaused, corev1.ConditionFalse, "AsExpected", "Reconciliation is not paused")
+	}
+	return paused, 0
+}
@@ -3,12 +3,14 @@ package pki
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/ks"
 )
 
-func RootCASecret(controlPlaneNamespace string) *corev1.Secret {
+func RootCASecret(controlPlaneNamespace, instance string) *corev1.Secret {
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "root-ca",
+			Name:      ks.Name(instance, "root-ca"),
 			Namespace: controlPlaneNamespace,
 		},
 	}
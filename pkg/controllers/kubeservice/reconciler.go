@@ -3,12 +3,17 @@ package kubeservice
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
+	routev1 "github.com/openshift/api/route/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -22,9 +27,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
-	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1alpha1"
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
 	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/etcd"
 	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/kas"
 	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/kcm"
@@ -35,35 +41,55 @@ import (
 )
 
 const (
-	defaultServiceCIDR = "172.30.0.0/16"
-	defaultPodCIDR     = "10.128.0.0/14"
-	etcdOperatorImage  = "quay.io/coreos/etcd-operator:v0.9.4"
-	etcdVersion        = "3.4.9"
-	kubeAPIServerPort  = 6443
+	etcdOperatorImage = "quay.io/coreos/etcd-operator:v0.9.4"
+	etcdVersion       = "3.4.9"
+	kubeAPIServerPort = 6443
 
 	kubeAPIServerReplicas         = 1
 	kubeControllerManagerReplicas = 1
 	etcdClusterReplicas           = 1
+
+	kubeAPIServerHAReplicas         = 3
+	kubeControllerManagerHAReplicas = 2
+	etcdClusterHAReplicas           = 3
 )
 
 type KubernetesServiceReconciler struct {
 	client.Client
 	Config *rest.Config
 
+	// Reader reads objects directly from the API server, so that the rollout
+	// of a deployment updated in the same reconcile is not missed.
+	Reader client.Reader
+
 	recorder         record.EventRecorder
 	releaseInfoCache map[string]*releaseinfo.ReleaseImage
 	cacheMutex       sync.Mutex
 }
 
 func (r *KubernetesServiceReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	_, err := ctrl.NewControllerManagedBy(mgr).
+	builder := ctrl.NewControllerManagedBy(mgr).
 		For(&hyperlitev1.KubernetesService{}).
 		WithOptions(controller.Options{
 			RateLimiter: workqueue.NewItemExponentialFailureRateLimiter(1*time.Second, 10*time.Second),
 		}).
 		Watches(&source.Kind{Type: &etcdv1.EtcdCluster{}}, &handler.EnqueueRequestForOwner{OwnerType: &hyperlitev1.KubernetesService{}}).
 		Watches(&source.Kind{Type: &appsv1.Deployment{}}, &handler.EnqueueRequestForOwner{OwnerType: &hyperlitev1.KubernetesService{}}).
-		Build(r)
+		Watches(&source.Kind{Type: &corev1.Service{}}, &handler.EnqueueRequestForOwner{OwnerType: &hyperlitev1.KubernetesService{}}).
+		Watches(&source.Kind{Type: &etcdv1.EtcdCluster{}}, handler.EnqueueRequestsFromMapFunc(r.kubeServiceForLabels)).
+		Watches(&source.Kind{Type: &appsv1.Deployment{}}, handler.EnqueueRequestsFromMapFunc(r.kubeServiceForLabels)).
+		Watches(&source.Kind{Type: &corev1.Service{}}, handler.EnqueueRequestsFromMapFunc(r.kubeServiceForLabels)).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(r.kubeServicesForSecret)).
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}}, handler.EnqueueRequestsFromMapFunc(r.kubeServicesForAuditPolicy))
+	// The external endpoint of a published API server depends on the host the
+	// router admits its Route with. Routes are only watched on clusters that
+	// serve them.
+	if _, err := mgr.GetRESTMapper().RESTMapping(routev1.GroupVersion.WithKind("Route").GroupKind(), routev1.GroupVersion.Version); err == nil {
+		builder = builder.
+			Watches(&source.Kind{Type: &routev1.Route{}}, &handler.EnqueueRequestForOwner{OwnerType: &hyperlitev1.KubernetesService{}}).
+			Watches(&source.Kind{Type: &routev1.Route{}}, handler.EnqueueRequestsFromMapFunc(r.kubeServiceForLabels))
+	}
+	_, err := builder.Build(r)
 	if err != nil {
 		return fmt.Errorf("failed setting up with a controller manager %w", err)
 	}
@@ -73,14 +99,30 @@ func (r *KubernetesServiceReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return nil
 }
 
-func (r *KubernetesServiceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+// kubeServicesForAuditPolicy maps a ConfigMap to the KubernetesServices that
+// reference it as their custom audit policy.
+func (r *KubernetesServiceReconciler) kubeServicesForAuditPolicy(obj client.Object) []reconcile.Request {
+	kubeServices := &hyperlitev1.KubernetesServiceList{}
+	if err := r.List(context.Background(), kubeServices, client.InNamespace(obj.GetNamespace())); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, kubeSvc := range kubeServices.Items {
+		if customPolicy := kubeSvc.Spec.Security.Audit.CustomPolicy; customPolicy != nil && customPolicy.Name == obj.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&kubeSvc)})
+		}
+	}
+	return requests
+}
+
+func (r *KubernetesServiceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	log := ctrl.LoggerFrom(ctx).WithValues("kubeService", req.NamespacedName.String())
 	log.Info("Reconciling KubernetesService")
 	ctx = ctrl.LoggerInto(ctx, log)
 
 	// Fetch the KubernetesService instance
 	kubeService := &hyperlitev1.KubernetesService{}
-	err := r.Client.Get(ctx, req.NamespacedName, kubeService)
+	err = r.Client.Get(ctx, req.NamespacedName, kubeService)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			return ctrl.Result{}, nil
@@ -88,15 +130,73 @@ func (r *KubernetesServiceReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return ctrl.Result{}, err
 	}
 
-	// Return early if deleted
+	// Only report status while reconciliation is paused
+	paused, resumeAfter := r.reconcilePausedCondition(kubeService)
+
+	// Tear down the control plane if deleted
 	if !kubeService.DeletionTimestamp.IsZero() {
-		return ctrl.Result{}, nil
+		if paused {
+			log.Info("Reconciliation is paused, not tearing down the control plane")
+			if err := r.Status().Update(ctx, kubeService); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to update kubernetes service status: %w", err)
+			}
+			return ctrl.Result{RequeueAfter: resumeAfter}, nil
+		}
+		return r.reconcileDeletion(ctx, kubeService)
+	}
+
+	// Delete the KubernetesService once its lifetime is over, and refresh
+	// the remaining lifetime in its status until then
+	expired, expiresIn, err := r.reconcileExpiration(ctx, kubeService, paused)
+	if err != nil || expired {
+		return ctrl.Result{}, err
+	}
+	if expiresIn > 0 {
+		defer func() {
+			if err == nil && (result.RequeueAfter == 0 || result.RequeueAfter > expiresIn) {
+				result.RequeueAfter = expiresIn
+			}
+		}()
+	}
+
+	// Keep the names of control planes created before names were derived
+	// from the KubernetesService name
+	if !paused {
+		if err := r.reconcileLegacyNames(ctx, kubeService); err != nil {
+			return ctrl.Result{}, err
+		}
 	}
 
+	// Validate the KubernetesService configuration
+	networking := ks.Networking(kubeService)
+	if reason, err := r.validateKubernetesService(ctx, kubeService, networking); err != nil {
+		log.Info("Invalid KubernetesService configuration", "reason", err.Error())
+		ks.SetConditionByType(&kubeService.Status.Conditions, hyperlitev1.ValidConfiguration, corev1.ConditionFalse, reason, err.Error())
+		kubeService.Status.ObservedGeneration = kubeService.Generation
+		if err := r.Status().Update(ctx, kubeService); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update kubernetes service status: %w", err)
+		}
+		// The configuration may refer to resources that do not exist yet
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	}
+	ks.SetConditionByType(&kubeService.Status.Conditions, hyperlitev1.ValidConfiguration, corev1.ConditionTrue, "AsExpected", "KubernetesService configuration is valid")
+	if kubeService.Status.Networking == nil {
+		kubeService.Status.Networking = &networking
+	}
+	if kubeService.Status.ControlPlaneNamespace == "" {
+		kubeService.Status.ControlPlaneNamespace = ks.ControlPlaneNamespace(kubeService)
+	}
+
+	// Control plane objects are named after the KubernetesService
+	namespace, instance := ks.ControlPlaneNamespace(kubeService), ks.InstanceName(kubeService)
+
+	// Components that are serving with fewer replicas than desired
+	var degradedComponents []string
+
 	// Reconcile etcd cluster status
 	{
 		log.Info("Reconciling Etcd status")
-		etcdCluster := etcd.Cluster(req.Namespace)
+		etcdCluster := etcd.Cluster(namespace, instance)
 		var err error
 		if err = r.Get(ctx, types.NamespacedName{Namespace: etcdCluster.Namespace, Name: etcdCluster.Name}, etcdCluster); err != nil && !apierrors.IsNotFound(err) {
 			return ctrl.Result{}, fmt.Errorf("failed to fetch etcd cluster %s/%s: %w", etcdCluster.Namespace, etcdCluster.Name, err)
@@ -110,16 +210,19 @@ func (r *KubernetesServiceReconciler) Reconcile(ctx context.Context, req ctrl.Re
 				return ctrl.Result{RequeueAfter: 5 * time.Second}, nil
 			}
 		}
-		err = etcd.ReconcileEtcdClusterStatus(ctx, r.Client, kubeService, etcdCluster)
+		err = etcd.ReconcileEtcdClusterStatus(ctx, r.Client, kubeService, etcdCluster, !paused && !ks.IsHibernating(kubeService))
 		if err != nil {
 			log.Error(err, "etcd status reconcile failed")
 			return ctrl.Result{}, err
 		}
+		if etcd.ClusterDegraded(etcdCluster) {
+			degradedComponents = append(degradedComponents, "etcd")
+		}
 	}
 	// Reconcile kas status
 	{
 		log.Info("Reconciling Kube APIServer status")
-		kasDeployment := kas.Deployment(req.Namespace)
+		kasDeployment := kas.Deployment(namespace, instance)
 		var err error
 		if err = r.Get(ctx, types.NamespacedName{Namespace: kasDeployment.Namespace, Name: kasDeployment.Name}, kasDeployment); err != nil && !apierrors.IsNotFound(err) {
 			return ctrl.Result{}, fmt.Errorf("failed to fetch kas deployment %s/%s: %w", kasDeployment.Namespace, kasDeployment.Name, err)
@@ -135,11 +238,14 @@ func (r *KubernetesServiceReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		if err != nil {
 			log.Error(err, "kube apiserver status reconcile failed")
 		}
+		if ks.DeploymentDegraded(kasDeployment) {
+			degradedComponents = append(degradedComponents, "kube-apiserver")
+		}
 	}
 	// Reconcile kcm status
 	{
 		log.Info("Reconciling Kube controller manager status")
-		kcmDeployment := kcm.Deployment(req.Namespace)
+		kcmDeployment := kcm.Deployment(namespace, instance)
 		var err error
 		if err = r.Get(ctx, types.NamespacedName{Namespace: kcmDeployment.Namespace, Name: kcmDeployment.Name}, kcmDeployment); err != nil && !apierrors.IsNotFound(err) {
 			return ctrl.Result{}, fmt.Errorf("failed to fetch kcm deployment %s/%s: %w", kcmDeployment.Namespace, kcmDeployment.Name, err)
@@ -156,6 +262,9 @@ func (r *KubernetesServiceReconciler) Reconcile(ctx context.Context, req ctrl.Re
 			log.Error(err, "kube apiserver status reconcile failed")
 			return ctrl.Result{}, err
 		}
+		if ks.DeploymentDegraded(kcmDeployment) {
+			degradedComponents = append(degradedComponents, "kube-controller-manager")
+		}
 	}
 	// Reconcile ks status
 	{
@@ -169,17 +278,48 @@ func (r *KubernetesServiceReconciler) Reconcile(ctx context.Context, req ctrl.Re
 			kasAvailable.Status == corev1.ConditionTrue &&
 			kcmAvailable.Status == corev1.ConditionTrue {
 			ks.SetConditionByType(&kubeService.Status.Conditions, hyperlitev1.Available, corev1.ConditionTrue, "Running", "Kubernetes service is up and running")
+		} else if ks.PowerState(kubeService) == hyperlitev1.HibernatingPowerState {
+			ks.SetConditionByType(&kubeService.Status.Conditions, hyperlitev1.Available, corev1.ConditionFalse, "Hibernated", "Kubernetes service is hibernated")
+		} else if kubeService.Status.Hibernation != nil {
+			ks.SetConditionByType(&kubeService.Status.Conditions, hyperlitev1.Available, corev1.ConditionFalse, "Resuming", "Kubernetes service is resuming from hibernation")
+		} else if !ks.SourceCompleted(kubeService) {
+			ks.SetConditionByType(&kubeService.Status.Conditions, hyperlitev1.Available, corev1.ConditionFalse, "Restoring", "Kubernetes service is being created from its source")
 		} else {
 			ks.SetConditionByType(&kubeService.Status.Conditions, hyperlitev1.Available, corev1.ConditionFalse, "NotAvailable", "Kubernetes service is not yet available")
 		}
+		if len(degradedComponents) > 0 {
+			ks.SetConditionByType(&kubeService.Status.Conditions, hyperlitev1.Degraded, corev1.ConditionTrue, "ComponentsDegraded", fmt.Sprintf("Components running with fewer replicas than desired: %s", strings.Join(degradedComponents, ", ")))
+		} else {
+			ks.SetConditionByType(&kubeService.Status.Conditions, hyperlitev1.Degraded, corev1.ConditionFalse, "AsExpected", "All components are running with their desired replicas")
+		}
+		kubeService.Status.ObservedGeneration = kubeService.Generation
 		if err := r.Status().Update(ctx, kubeService); err != nil {
 			log.Error(err, "failed to update kubernetes service status")
 			return ctrl.Result{}, err
 		}
 	}
+	if paused {
+		log.Info("Reconciliation is paused")
+		return ctrl.Result{RequeueAfter: resumeAfter}, nil
+	}
+
+	// Tear down the control plane in order when the KubernetesService is deleted
+	if err := r.reconcileFinalizer(ctx, kubeService); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Reconcile the dedicated control plane namespace
+	if err := r.reconcileControlPlaneNamespace(ctx, kubeService); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Hibernate or resume the control plane
+	if skip, result, err := r.reconcilePowerState(ctx, kubeService); skip || err != nil {
+		return result, err
+	}
 
 	// Reconcile root CA
-	rootCASecret := pki.RootCASecret(kubeService.Namespace)
+	rootCASecret := pki.RootCASecret(namespace, instance)
 	if _, err = controllerutil.CreateOrUpdate(ctx, r, rootCASecret, func() error {
 		ensureKSOwnerRef(kubeService, rootCASecret)
 		return pki.ReconcileRootCA(rootCASecret)
@@ -193,6 +333,15 @@ func (r *KubernetesServiceReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		log.Error(err, "failed to reconcile etcd")
 		return ctrl.Result{}, err
 	}
+
+	// Restore etcd from the source of the control plane
+	restored, err := r.reconcileSourceRestore(ctx, kubeService)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !restored {
+		return ctrl.Result{RequeueAfter: restorePollInterval}, nil
+	}
 	{
 		etcdAvailable := ks.GetConditionByType(kubeService.Status.Conditions, hyperlitev1.EtcdAvailable)
 		if etcdAvailable == nil || etcdAvailable.Status != corev1.ConditionTrue {
@@ -201,19 +350,25 @@ func (r *KubernetesServiceReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		}
 	}
 
-	// Get release image info
-	releaseImage, err := r.getReleaseImage(ctx, req.Namespace, kubeService.Spec.ReleaseImage, kubeService.Spec.PullSecret.Name)
+	// Get the release images of the components, upgrading them in order
+	rollout, err := r.reconcileRelease(ctx, kubeService)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
+	if err := r.reconcileVersionStatus(ctx, kubeService, rollout.current); err != nil {
+		return ctrl.Result{}, err
+	}
 
 	// Reconcile K8s API server
 	log.Info("Reconciling Kube API server")
-	err = r.reconcileKubeAPIServer(ctx, kubeService, releaseImage)
+	err = r.reconcileKubeAPIServer(ctx, kubeService, networking, rollout.kubeAPIServer)
 	if err != nil {
 		log.Error(err, "failed to reconcile kube api server")
 		return ctrl.Result{}, err
 	}
+	if result, err := r.reconcileKubeAPIServerUpgrade(ctx, kubeService, rollout); err != nil || !result.IsZero() {
+		return result, err
+	}
 	{
 		kasAvailable := ks.GetConditionByType(kubeService.Status.Conditions, hyperlitev1.KubeAPIServerAvailable)
 		if kasAvailable == nil || kasAvailable.Status != corev1.ConditionTrue {
@@ -222,16 +377,32 @@ func (r *KubernetesServiceReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		}
 	}
 
+	// Make a control plane created from a source independent of it
+	if err := r.reconcileRestoredCluster(ctx, kubeService, networking); err != nil {
+		log.Error(err, "failed to reconcile restored cluster")
+		return ctrl.Result{}, err
+	}
+
 	// Reconcile Kube controller manager
 	log.Info("Reconciling Kube Controller Manager")
-	err = r.reconcileKubeControllerManager(ctx, kubeService, releaseImage)
+	err = r.reconcileKubeControllerManager(ctx, kubeService, networking, rollout.kubeControllerManager)
 	if err != nil {
 		log.Error(err, "failed to reconcile kube controller manager")
 		return ctrl.Result{}, err
 	}
+	if result, err := r.reconcileUpgradeCompletion(ctx, kubeService, rollout); err != nil || !result.IsZero() {
+		return result, err
+	}
+
+	// Rotate the secret encryption keys
+	result, err = r.reconcileSecretEncryptionRotation(ctx, kubeService)
+	if err != nil {
+		log.Error(err, "failed to rotate secret encryption keys")
+		return ctrl.Result{}, err
+	}
 
 	log.Info("Reconciliation completed")
-	return ctrl.Result{}, nil
+	return result, nil
 }
 
 func (r *KubernetesServiceReconciler) getReleaseImage(ctx context.Context, namespace, imagePullSpec, pullSecretName string) (*releaseinfo.ReleaseImage, error) {
@@ -260,14 +431,66 @@ func (r *KubernetesServiceReconciler) getReleaseImage(ctx context.Context, names
 	return img, err
 }
 
+// reconcileVersionStatus records the version, component versions and digest of
+// the release image in status.
+func (r *KubernetesServiceReconciler) reconcileVersionStatus(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService, releaseImage *releaseinfo.ReleaseImage) error {
+	status := kubeSvc.Status.DeepCopy()
+	status.Version = releaseImage.Version()
+	status.ReleaseImageDigest = releaseImage.Digest
+	componentVersions, err := releaseImage.ComponentVersions()
+	if err != nil {
+		// Versions that cannot be read are omitted rather than blocking the rollout
+		ctrl.LoggerFrom(ctx).Error(err, "failed to read component versions of release image")
+	} else {
+		status.ComponentVersions = componentVersions
+	}
+	if equality.Semantic.DeepEqual(status, &kubeSvc.Status) {
+		return nil
+	}
+	kubeSvc.Status = *status
+	if err := r.Status().Update(ctx, kubeSvc); err != nil {
+		return fmt.Errorf("failed to update release version status: %w", err)
+	}
+	return nil
+}
+
+// reconcileEndpointStatus records the internal endpoint of the API server and
+// the kubeconfig secrets that give access to it in status. The localhost
+// kubeconfig is omitted when it is nil.
+func (r *KubernetesServiceReconciler) reconcileEndpointStatus(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService, kubeAPIServerService *corev1.Service, serviceKubeconfig, localhostKubeconfig, externalKubeconfig *corev1.Secret, published bool) error {
+	status := kubeSvc.Status.DeepCopy()
+	status.InternalAPIEndpoint = &hyperlitev1.APIEndpoint{
+		Host: fmt.Sprintf("%s.%s.svc", kubeAPIServerService.Name, kubeAPIServerService.Namespace),
+		Port: kubeAPIServerPort,
+	}
+	status.Kubeconfigs = hyperlitev1.KubeconfigSecrets{
+		Service: &corev1.LocalObjectReference{Name: serviceKubeconfig.Name},
+	}
+	if localhostKubeconfig != nil {
+		status.Kubeconfigs.Localhost = &corev1.LocalObjectReference{Name: localhostKubeconfig.Name}
+	}
+	if published {
+		status.Kubeconfigs.External = &corev1.LocalObjectReference{Name: externalKubeconfig.Name}
+	}
+	if equality.Semantic.DeepEqual(status, &kubeSvc.Status) {
+		return nil
+	}
+	kubeSvc.Status = *status
+	if err := r.Status().Update(ctx, kubeSvc); err != nil {
+		return fmt.Errorf("failed to update api server endpoint status: %w", err)
+	}
+	return nil
+}
+
 func (r *KubernetesServiceReconciler) reconcileEtcd(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService) error {
-	rootCASecret := pki.RootCASecret(kubeSvc.Namespace)
+	namespace, instance := ks.ControlPlaneNamespace(kubeSvc), ks.InstanceName(kubeSvc)
+	rootCASecret := pki.RootCASecret(namespace, instance)
 	if err := r.Get(ctx, client.ObjectKeyFromObject(rootCASecret), rootCASecret); err != nil {
 		return fmt.Errorf("cannot get root CA secret: %w", err)
 	}
 
 	// Etcd client secret
-	clientSecret := etcd.ClientSecret(kubeSvc.Namespace)
+	clientSecret := etcd.ClientSecret(namespace, instance)
 	if err := r.Get(ctx, client.ObjectKeyFromObject(clientSecret), clientSecret); err != nil && !apierrors.IsNotFound(err) {
 		return fmt.Errorf("cannot get client secret: %w", err)
 	}
@@ -279,31 +502,31 @@ func (r *KubernetesServiceReconciler) reconcileEtcd(ctx context.Context, kubeSvc
 	}
 
 	// Etcd server secret
-	serverSecret := etcd.ServerSecret(kubeSvc.Namespace)
+	serverSecret := etcd.ServerSecret(namespace, instance)
 	if err := r.Get(ctx, client.ObjectKeyFromObject(clientSecret), clientSecret); err != nil && !apierrors.IsNotFound(err) {
 		return fmt.Errorf("cannot get server secret: %w", err)
 	}
 	if _, err := controllerutil.CreateOrUpdate(ctx, r, serverSecret, func() error {
 		ensureKSOwnerRef(kubeSvc, serverSecret)
-		return etcd.ReconcileServerSecret(serverSecret, rootCASecret)
+		return etcd.ReconcileServerSecret(serverSecret, rootCASecret, instance)
 	}); err != nil {
 		return fmt.Errorf("failed to reconcile etcd server secret: %w", err)
 	}
 
 	// Etcd peer secret
-	peerSecret := etcd.PeerSecret(kubeSvc.Namespace)
+	peerSecret := etcd.PeerSecret(namespace, instance)
 	if err := r.Get(ctx, client.ObjectKeyFromObject(clientSecret), clientSecret); err != nil && !apierrors.IsNotFound(err) {
 		return fmt.Errorf("cannot get peer secret: %w", err)
 	}
 	if _, err := controllerutil.CreateOrUpdate(ctx, r, peerSecret, func() error {
 		ensureKSOwnerRef(kubeSvc, peerSecret)
-		return etcd.ReconcilePeerSecret(peerSecret, rootCASecret)
+		return etcd.ReconcilePeerSecret(peerSecret, rootCASecret, instance)
 	}); err != nil {
 		return fmt.Errorf("failed to reconcile etcd peer secret: %w", err)
 	}
 
 	// Etcd Operator ServiceAccount
-	operatorServiceAccount := etcd.OperatorServiceAccount(kubeSvc.Namespace)
+	operatorServiceAccount := etcd.OperatorServiceAccount(namespace, instance)
 	if err := r.Get(ctx, client.ObjectKeyFromObject(operatorServiceAccount), operatorServiceAccount); err != nil && !apierrors.IsNotFound(err) {
 		return fmt.Errorf("cannot get etcd operator service account: %w", err)
 	}
@@ -315,7 +538,7 @@ func (r *KubernetesServiceReconciler) reconcileEtcd(ctx context.Context, kubeSvc
 	}
 
 	// Etcd operator role
-	operatorRole := etcd.OperatorRole(kubeSvc.Namespace)
+	operatorRole := etcd.OperatorRole(namespace, instance)
 	if err := r.Get(ctx, client.ObjectKeyFromObject(operatorRole), operatorRole); err != nil && !apierrors.IsNotFound(err) {
 		return fmt.Errorf("cannot get etcd operator role: %w", err)
 	}
@@ -327,73 +550,97 @@ func (r *KubernetesServiceReconciler) reconcileEtcd(ctx context.Context, kubeSvc
 	}
 
 	// Etcd operator rolebinding
-	operatorRoleBinding := etcd.OperatorRoleBinding(kubeSvc.Namespace)
+	operatorRoleBinding := etcd.OperatorRoleBinding(namespace, instance)
 	if err := r.Get(ctx, client.ObjectKeyFromObject(operatorRoleBinding), operatorRoleBinding); err != nil && !apierrors.IsNotFound(err) {
 		return fmt.Errorf("cannot get etcd operator role binding: %w", err)
 	}
 	if _, err := controllerutil.CreateOrUpdate(ctx, r, operatorRoleBinding, func() error {
 		ensureKSOwnerRef(kubeSvc, operatorRoleBinding)
-		return etcd.ReconcileOperatorRoleBinding(operatorRoleBinding)
+		return etcd.ReconcileOperatorRoleBinding(operatorRoleBinding, instance)
 	}); err != nil {
 		return fmt.Errorf("failed to reconcile etcd operator role binding: %w", err)
 	}
 
 	// Etcd operator deployment
-	operatorDeployment := etcd.OperatorDeployment(kubeSvc.Namespace)
+	operatorDeployment := etcd.OperatorDeployment(namespace, instance)
 	if err := r.Get(ctx, client.ObjectKeyFromObject(operatorDeployment), operatorDeployment); err != nil && !apierrors.IsNotFound(err) {
 		return fmt.Errorf("cannot get etcd operator deployment: %w", err)
 	}
 	if _, err := controllerutil.CreateOrUpdate(ctx, r, operatorDeployment, func() error {
 		ensureKSOwnerRef(kubeSvc, operatorDeployment)
-		return etcd.ReconcileOperatorDeployment(operatorDeployment, etcdOperatorImage)
+		return etcd.ReconcileOperatorDeployment(operatorDeployment, instance, etcdOperatorImage, &kubeSvc.Spec.Components.EtcdOperator)
 	}); err != nil {
 		return fmt.Errorf("failed to reconcile etcd operator deployment: %w", err)
 	}
 
+	// The etcd cluster is created by the etcd restore operator when restored
+	// from a source
+	if ks.RestoringSource(kubeSvc) {
+		return nil
+	}
+
 	// Etcd cluster
-	etcdCluster := etcd.Cluster(kubeSvc.Namespace)
+	etcdSize := replicasFor(kubeSvc, etcdClusterReplicas, etcdClusterHAReplicas)
+	etcdCluster := etcd.Cluster(namespace, instance)
 	if err := r.Get(ctx, client.ObjectKeyFromObject(etcdCluster), etcdCluster); err != nil && !apierrors.IsNotFound(err) {
 		return fmt.Errorf("cannot get etcd cluster: %w", err)
 	}
 	if _, err := controllerutil.CreateOrUpdate(ctx, r, etcdCluster, func() error {
 		ensureKSOwnerRef(kubeSvc, etcdCluster)
-		return etcd.ReconcileCluster(etcdCluster, etcdClusterReplicas, etcdVersion)
+		return etcd.ReconcileCluster(etcdCluster, instance, etcdSize, etcdVersion, &kubeSvc.Spec.Components.Etcd)
 	}); err != nil {
 		return fmt.Errorf("failed to reconcile etcd cluster: %w", err)
 	}
 
+	// Etcd pod disruption budget
+	if err := r.reconcilePodDisruptionBudget(ctx, kubeSvc, etcd.PodDisruptionBudget(namespace, instance), func(pdb *policyv1beta1.PodDisruptionBudget) error {
+		return etcd.ReconcilePodDisruptionBudget(pdb, instance, etcdSize)
+	}); err != nil {
+		return err
+	}
+
 	return nil
 }
 
-func (r *KubernetesServiceReconciler) reconcileKubeAPIServer(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService, imageInfo *releaseinfo.ReleaseImage) error {
-	rootCASecret := pki.RootCASecret(kubeSvc.Namespace)
+func (r *KubernetesServiceReconciler) reconcileKubeAPIServer(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService, networking hyperlitev1.ClusterNetworkSpec, imageInfo *releaseinfo.ReleaseImage) error {
+	namespace, instance := ks.ControlPlaneNamespace(kubeSvc), ks.InstanceName(kubeSvc)
+	rootCASecret := pki.RootCASecret(namespace, instance)
 	if err := r.Get(ctx, client.ObjectKeyFromObject(rootCASecret), rootCASecret); err != nil {
 		return fmt.Errorf("cannot get root CA secret: %w", err)
 	}
 
-	kubeAPIServerService := kas.Service(kubeSvc.Namespace)
+	kubeAPIServerService := kas.Service(namespace, instance)
 	if err := r.Get(ctx, client.ObjectKeyFromObject(kubeAPIServerService), kubeAPIServerService); err != nil && !apierrors.IsNotFound(err) {
 		return fmt.Errorf("cannot get api server service: %w", err)
 	}
 	if _, err := controllerutil.CreateOrUpdate(ctx, r, kubeAPIServerService, func() error {
 		ensureKSOwnerRef(kubeSvc, kubeAPIServerService)
-		return kas.ReconcileService(kubeAPIServerService, kubeAPIServerPort, kubeAPIServerPort)
+		return kas.ReconcileService(kubeAPIServerService, instance, kubeAPIServerPort, kubeAPIServerPort, kubeSvc.Spec.Networking.APIServerPublishing)
 	}); err != nil {
 		return fmt.Errorf("failed to reconcile api server service: %w", err)
 	}
 
-	kubeAPIServerCertSecret := kas.ServerCertSecret(kubeSvc.Namespace)
+	externalEndpoint, err := r.reconcileAPIServerPublishing(ctx, kubeSvc, kubeAPIServerService)
+	if err != nil {
+		return err
+	}
+	externalHost := ""
+	if externalEndpoint != nil {
+		externalHost = externalEndpoint.Host
+	}
+
+	kubeAPIServerCertSecret := kas.ServerCertSecret(namespace, instance)
 	if err := r.Get(ctx, client.ObjectKeyFromObject(kubeAPIServerCertSecret), kubeAPIServerCertSecret); err != nil && !apierrors.IsNotFound(err) {
 		return fmt.Errorf("cannot get api server cert secret: %w", err)
 	}
 	if _, err := controllerutil.CreateOrUpdate(ctx, r, kubeAPIServerCertSecret, func() error {
 		ensureKSOwnerRef(kubeSvc, kubeAPIServerCertSecret)
-		return kas.ReconcileServerCertSecret(kubeAPIServerCertSecret, rootCASecret, defaultServiceCIDR)
+		return kas.ReconcileServerCertSecret(kubeAPIServerCertSecret, rootCASecret, instance, networking.ServiceCIDR, networking.ClusterDomain, externalHost)
 	}); err != nil {
 		return fmt.Errorf("failed to reconcile api server cert secret: %w", err)
 	}
 
-	kubeAPIServerAggregatorCertSecret := kas.AggregatorCertSecret(kubeSvc.Namespace)
+	kubeAPIServerAggregatorCertSecret := kas.AggregatorCertSecret(namespace, instance)
 	if err := r.Get(ctx, client.ObjectKeyFromObject(kubeAPIServerAggregatorCertSecret), kubeAPIServerAggregatorCertSecret); err != nil && !apierrors.IsNotFound(err) {
 		return fmt.Errorf("cannot get api server aggreator cert secret: %w", err)
 	}
@@ -404,7 +651,7 @@ func (r *KubernetesServiceReconciler) reconcileKubeAPIServer(ctx context.Context
 		return fmt.Errorf("failed to reconcile api server aggregator cert secret: %w", err)
 	}
 
-	serviceAccountSigningKeySecret := kas.ServiceAccountSigningKeySecret(kubeSvc.Namespace)
+	serviceAccountSigningKeySecret := kas.ServiceAccountSigningKeySecret(namespace, instance)
 	if err := r.Get(ctx, client.ObjectKeyFromObject(serviceAccountSigningKeySecret), serviceAccountSigningKeySecret); err != nil && !apierrors.IsNotFound(err) {
 		return fmt.Errorf("cannot get api server service account key secret: %w", err)
 	}
@@ -415,18 +662,18 @@ func (r *KubernetesServiceReconciler) reconcileKubeAPIServer(ctx context.Context
 		return fmt.Errorf("failed to reconcile api server service account key secret: %w", err)
 	}
 
-	serviceKubeconfigSecret := kas.ServiceKubeconfigSecret(kubeSvc.Namespace)
+	serviceKubeconfigSecret := kas.ServiceKubeconfigSecret(namespace, instance)
 	if err := r.Get(ctx, client.ObjectKeyFromObject(serviceKubeconfigSecret), serviceKubeconfigSecret); err != nil && !apierrors.IsNotFound(err) {
 		return fmt.Errorf("cannot get service admin kubeconfig secret: %w", err)
 	}
 	if _, err := controllerutil.CreateOrUpdate(ctx, r, serviceKubeconfigSecret, func() error {
 		ensureKSOwnerRef(kubeSvc, serviceKubeconfigSecret)
-		return kas.ReconcileServiceKubeconfigSecret(serviceKubeconfigSecret, rootCASecret, kubeAPIServerPort)
+		return kas.ReconcileServiceKubeconfigSecret(serviceKubeconfigSecret, rootCASecret, instance, kubeAPIServerPort)
 	}); err != nil {
 		return fmt.Errorf("failed to reconcile service admin kubeconfig secret: %w", err)
 	}
 
-	localhostKubeconfigSecret := kas.LocalhostKubeconfigSecret(kubeSvc.Namespace)
+	localhostKubeconfigSecret := kas.LocalhostKubeconfigSecret(namespace, instance)
 	if err := r.Get(ctx, client.ObjectKeyFromObject(localhostKubeconfigSecret), localhostKubeconfigSecret); err != nil && !apierrors.IsNotFound(err) {
 		return fmt.Errorf("cannot get service localhost kubeconfig secret: %w", err)
 	}
@@ -437,29 +684,85 @@ func (r *KubernetesServiceReconciler) reconcileKubeAPIServer(ctx context.Context
 		return fmt.Errorf("failed to reconcile localhost kubeconfig secret: %w", err)
 	}
 
-	kubeAPIServerAuditConfig := kas.AuditConfig(kubeSvc.Namespace)
+	externalKubeconfigSecret := kas.ExternalKubeconfigSecret(namespace, instance)
+	if externalEndpoint != nil {
+		externalServerCA, err := r.externalServerCA(ctx, kubeSvc, rootCASecret, externalEndpoint.Host)
+		if err != nil {
+			return err
+		}
+		if _, err := controllerutil.CreateOrUpdate(ctx, r, externalKubeconfigSecret, func() error {
+			ensureKSOwnerRef(kubeSvc, externalKubeconfigSecret)
+			return kas.ReconcileExternalKubeconfigSecret(externalKubeconfigSecret, rootCASecret, externalEndpoint.Host, int(externalEndpoint.Port), externalServerCA)
+		}); err != nil {
+			return fmt.Errorf("failed to reconcile external kubeconfig secret: %w", err)
+		}
+	} else if err := r.deleteIfExists(ctx, externalKubeconfigSecret); err != nil {
+		return fmt.Errorf("failed to remove external kubeconfig secret: %w", err)
+	}
+
+	// Kubeconfigs in a dedicated namespace are exported to the namespace of
+	// the KubernetesService, except for the localhost one which only works
+	// from within the API server pods
+	if ks.IsDedicatedNamespace(kubeSvc) {
+		serviceKubeconfigSecret, externalKubeconfigSecret, err = r.reconcileExportedKubeconfigs(ctx, kubeSvc, rootCASecret, kubeAPIServerService, externalKubeconfigSecret, externalEndpoint != nil)
+		if err != nil {
+			return err
+		}
+		localhostKubeconfigSecret = nil
+	}
+	if err := r.reconcileEndpointStatus(ctx, kubeSvc, kubeAPIServerService, serviceKubeconfigSecret, localhostKubeconfigSecret, externalKubeconfigSecret, externalEndpoint != nil); err != nil {
+		return err
+	}
+
+	auditPolicy, err := r.auditPolicy(ctx, kubeSvc)
+	if err != nil {
+		return err
+	}
+	kubeAPIServerAuditConfig := kas.AuditConfig(namespace, instance)
 	if err := r.Get(ctx, client.ObjectKeyFromObject(kubeAPIServerAuditConfig), kubeAPIServerAuditConfig); err != nil && !apierrors.IsNotFound(err) {
 		return fmt.Errorf("cannot get api server audit config: %w", err)
 	}
 	if _, err := controllerutil.CreateOrUpdate(ctx, r, kubeAPIServerAuditConfig, func() error {
 		ensureKSOwnerRef(kubeSvc, kubeAPIServerAuditConfig)
-		return kas.ReconcileAuditConfig(kubeAPIServerAuditConfig)
+		return kas.ReconcileAuditConfig(kubeAPIServerAuditConfig, auditPolicy)
 	}); err != nil {
 		return fmt.Errorf("failed to reconcile api server audit config: %w", err)
 	}
 
-	kubeAPIServerConfig := kas.Config(kubeSvc.Namespace)
+	encryptionConfig, err := r.reconcileSecretEncryption(ctx, kubeSvc)
+	if err != nil {
+		return err
+	}
+	configObjects := []metav1.Object{kubeAPIServerAuditConfig}
+	if encryptionConfig != nil {
+		configObjects = append(configObjects, encryptionConfig)
+	}
+
+	kubeAPIServerConfig := kas.Config(namespace, instance)
 	if err := r.Get(ctx, client.ObjectKeyFromObject(kubeAPIServerConfig), kubeAPIServerConfig); err != nil && !apierrors.IsNotFound(err) {
 		return fmt.Errorf("cannot get api server config: %w", err)
 	}
 	if _, err := controllerutil.CreateOrUpdate(ctx, r, kubeAPIServerConfig, func() error {
 		ensureKSOwnerRef(kubeSvc, kubeAPIServerConfig)
-		return kas.ReconcileConfig(kubeAPIServerConfig, defaultServiceCIDR, kubeAPIServerPort)
+		return kas.ReconcileConfig(kubeAPIServerConfig, kas.ConfigParams{
+			InternalAPIServerPort: kubeAPIServerPort,
+			Instance:              instance,
+			ServiceCIDR:           networking.ServiceCIDR,
+			PodCIDR:               networking.PodCIDR,
+			AdvertiseAddress:      networking.AdvertiseAddress,
+			NamedCerts:            kubeSvc.Spec.Components.KubeAPIServer.ServingCerts,
+			ExtraArgs:             kubeSvc.Spec.Components.KubeAPIServer.ExtraArgs,
+			FeatureGates:          kubeSvc.Spec.Components.FeatureGates,
+			Admission:             kubeSvc.Spec.Components.KubeAPIServer.Admission,
+			Authentication:        kubeSvc.Spec.Security.Authentication,
+			Authorization:         kubeSvc.Spec.Security.Authorization,
+			SecretEncryption:      encryptionConfig != nil,
+		})
 	}); err != nil {
 		return fmt.Errorf("failed to reconcile api server config: %w", err)
 	}
 
-	oauthMetadata := kas.OAuthMetadata(kubeSvc.Namespace)
+	oauthMetadata := kas.OAuthMetadata(namespace, instance)
 	if err := r.Get(ctx, client.ObjectKeyFromObject(oauthMetadata), oauthMetadata); err != nil && !apierrors.IsNotFound(err) {
 		return fmt.Errorf("cannot get oauth metadata: %w", err)
 	}
@@ -470,34 +773,50 @@ func (r *KubernetesServiceReconciler) reconcileKubeAPIServer(ctx context.Context
 		return fmt.Errorf("failed to reconcile oauth metadata: %w", err)
 	}
 
-	kubeAPIServerDeployment := kas.Deployment(kubeSvc.Namespace)
+	kubeAPIServerDeployment := kas.Deployment(namespace, instance)
 	if err := r.Get(ctx, client.ObjectKeyFromObject(kubeAPIServerDeployment), kubeAPIServerDeployment); err != nil && !apierrors.IsNotFound(err) {
 		return fmt.Errorf("cannot get api server deployment: %w", err)
 	}
 	if _, err := controllerutil.CreateOrUpdate(ctx, r, kubeAPIServerDeployment, func() error {
 		ensureKSOwnerRef(kubeSvc, kubeAPIServerDeployment)
 		images := imageInfo.ComponentImages()
-		return kas.ReconcileKubeAPIServerDeployment(
-			kubeAPIServerDeployment,
-			images["cluster-config-operator"],
-			images["cli"],
-			images["hyperkube"],
-			kubeAPIServerPort,
-			kubeAPIServerReplicas)
+		return kas.ReconcileKubeAPIServerDeployment(kubeAPIServerDeployment, kas.DeploymentParams{
+			Instance:              instance,
+			ConfigOperatorImage:   images["cluster-config-operator"],
+			CLIImage:              images["cli"],
+			HyperKubeImage:        images["hyperkube"],
+			InternalAPIServerPort: kubeAPIServerPort,
+			Replicas:              replicasFor(kubeSvc, kubeAPIServerReplicas, kubeAPIServerHAReplicas),
+			Component:             &kubeSvc.Spec.Components.KubeAPIServer.ComponentSpec,
+			NamedCerts:            kubeSvc.Spec.Components.KubeAPIServer.ServingCerts,
+			Authentication:        kubeSvc.Spec.Security.Authentication,
+			Authorization:         kubeSvc.Spec.Security.Authorization,
+			SecretEncryption:      encryptionConfig != nil,
+			KMSPlugin:             kmsPlugin(kubeSvc),
+			ConfigHash:            kas.ConfigHash(append(configObjects, kubeAPIServerConfig)...),
+			EncryptionConfigHash:  encryptionConfigHash(encryptionConfig),
+		})
 	}); err != nil {
 		return fmt.Errorf("failed to reconcile api server service account key secret: %w", err)
 	}
 
+	if err := r.reconcilePodDisruptionBudget(ctx, kubeSvc, kas.PodDisruptionBudget(namespace, instance), func(pdb *policyv1beta1.PodDisruptionBudget) error {
+		return kas.ReconcilePodDisruptionBudget(pdb, instance)
+	}); err != nil {
+		return err
+	}
+
 	return nil
 }
 
-func (r *KubernetesServiceReconciler) reconcileKubeControllerManager(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService, imageInfo *releaseinfo.ReleaseImage) error {
-	rootCASecret := pki.RootCASecret(kubeSvc.Namespace)
+func (r *KubernetesServiceReconciler) reconcileKubeControllerManager(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService, networking hyperlitev1.ClusterNetworkSpec, imageInfo *releaseinfo.ReleaseImage) error {
+	namespace, instance := ks.ControlPlaneNamespace(kubeSvc), ks.InstanceName(kubeSvc)
+	rootCASecret := pki.RootCASecret(namespace, instance)
 	if err := r.Get(ctx, client.ObjectKeyFromObject(rootCASecret), rootCASecret); err != nil {
 		return fmt.Errorf("cannot get root CA secret: %w", err)
 	}
 
-	signerSecret := kcm.ClusterSignerSecret(kubeSvc.Namespace)
+	signerSecret := kcm.ClusterSignerSecret(namespace, instance)
 	if err := r.Get(ctx, client.ObjectKeyFromObject(signerSecret), signerSecret); err != nil && !apierrors.IsNotFound(err) {
 		return fmt.Errorf("cannot get api server cert secret: %w", err)
 	}
@@ -508,7 +827,7 @@ func (r *KubernetesServiceReconciler) reconcileKubeControllerManager(ctx context
 		return fmt.Errorf("failed to reconcile api server cert secret: %w", err)
 	}
 
-	config := kcm.Config(kubeSvc.Namespace)
+	config := kcm.Config(namespace, instance)
 	if err := r.Get(ctx, client.ObjectKeyFromObject(config), config); err != nil && !apierrors.IsNotFound(err) {
 		return fmt.Errorf("cannot get controller manager config: %w", err)
 	}
@@ -519,21 +838,181 @@ func (r *KubernetesServiceReconciler) reconcileKubeControllerManager(ctx context
 		return fmt.Errorf("failed to reconcile controller manager config: %w", err)
 	}
 
-	deployment := kcm.Deployment(kubeSvc.Namespace)
+	deployment := kcm.Deployment(namespace, instance)
 	if err := r.Get(ctx, client.ObjectKeyFromObject(deployment), deployment); err != nil && !apierrors.IsNotFound(err) {
 		return fmt.Errorf("cannot get controller manager deployment: %w", err)
 	}
 	if _, err := controllerutil.CreateOrUpdate(ctx, r, deployment, func() error {
 		images := imageInfo.ComponentImages()
 		ensureKSOwnerRef(kubeSvc, deployment)
-		return kcm.ReconcileDeployment(deployment, defaultPodCIDR, defaultServiceCIDR, images["hyperkube"], kubeControllerManagerReplicas)
+		return kcm.ReconcileDeployment(
+			deployment,
+			instance,
+			networking.PodCIDR,
+			networking.ServiceCIDR,
+			images["hyperkube"],
+			replicasFor(kubeSvc, kubeControllerManagerReplicas, kubeControllerManagerHAReplicas),
+			&kubeSvc.Spec.Components.KubeControllerManager.ComponentSpec,
+			kubeSvc.Spec.Components.KubeControllerManager.ExtraArgs,
+			kubeSvc.Spec.Components.FeatureGates)
 	}); err != nil {
 		return fmt.Errorf("failed to reconcile controller manager deployment: %w", err)
 	}
+
+	if err := r.reconcilePodDisruptionBudget(ctx, kubeSvc, kcm.PodDisruptionBudget(namespace, instance), func(pdb *policyv1beta1.PodDisruptionBudget) error {
+		return kcm.ReconcilePodDisruptionBudget(pdb, instance)
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// reconcileAPIServerPublishing creates the Route or Ingress used to publish the
+// API server according to its publishing strategy, removes the ones no longer
+// in use and records the resulting external endpoint in status.
+func (r *KubernetesServiceReconciler) reconcileAPIServerPublishing(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService, kubeAPIServerService *corev1.Service) (*hyperlitev1.APIEndpoint, error) {
+	publishing := kubeSvc.Spec.Networking.APIServerPublishing
+	namespace, instance := ks.ControlPlaneNamespace(kubeSvc), ks.InstanceName(kubeSvc)
+
+	var route *routev1.Route
+	if publishing.Type == hyperlitev1.RoutePublishing {
+		route = kas.Route(namespace, instance)
+		if _, err := controllerutil.CreateOrUpdate(ctx, r, route, func() error {
+			ensureKSOwnerRef(kubeSvc, route)
+			return kas.ReconcileRoute(route, instance, publishing.Hostname)
+		}); err != nil {
+			return nil, fmt.Errorf("failed to reconcile api server route: %w", err)
+		}
+	} else if err := r.deleteIfExists(ctx, kas.Route(namespace, instance)); err != nil && !meta.IsNoMatchError(err) {
+		return nil, fmt.Errorf("failed to remove api server route: %w", err)
+	}
+
+	if publishing.Type == hyperlitev1.IngressPublishing {
+		ingress := kas.Ingress(namespace, instance)
+		if _, err := controllerutil.CreateOrUpdate(ctx, r, ingress, func() error {
+			ensureKSOwnerRef(kubeSvc, ingress)
+			return kas.ReconcileIngress(ingress, instance, publishing.Hostname, publishing.IngressClassName, kubeAPIServerPort)
+		}); err != nil {
+			return nil, fmt.Errorf("failed to reconcile api server ingress: %w", err)
+		}
+	} else if err := r.deleteIfExists(ctx, kas.Ingress(namespace, instance)); err != nil {
+		return nil, fmt.Errorf("failed to remove api server ingress: %w", err)
+	}
+
+	endpoint := kas.ExternalAPIEndpoint(publishing, kubeAPIServerService, route, kubeAPIServerPort)
+	if !equality.Semantic.DeepEqual(endpoint, kubeSvc.Status.ExternalAPIEndpoint) {
+		kubeSvc.Status.ExternalAPIEndpoint = endpoint
+		if err := r.Status().Update(ctx, kubeSvc); err != nil {
+			return nil, fmt.Errorf("failed to update external api endpoint in status: %w", err)
+		}
+	}
+	return endpoint, nil
+}
+
+// externalServerCA returns the certificate authority bundle clients need to
+// verify the API server at its external host. When a named serving certificate
+// is served for the host, its ca.crt is used, or the client's system trust
+// store if it has none. Otherwise the root CA is used.
+func (r *KubernetesServiceReconciler) externalServerCA(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService, rootCASecret *corev1.Secret, host string) ([]byte, error) {
+	for _, namedCert := range kubeSvc.Spec.Components.KubeAPIServer.ServingCerts {
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: kubeSvc.Namespace, Name: namedCert.ServingCertificate.Name}, secret); err != nil {
+			return nil, fmt.Errorf("cannot get serving certificate secret %s: %w", namedCert.ServingCertificate.Name, err)
+		}
+		if kas.NamedCertServesHost(namedCert, secret, host) {
+			return secret.Data[pki.CASignerCertMapKey], nil
+		}
+	}
+	return rootCASecret.Data[pki.CASignerCertMapKey], nil
+}
+
+// auditPolicy returns the audit policy of the API server, read from the custom
+// policy ConfigMap if one is referenced.
+func (r *KubernetesServiceReconciler) auditPolicy(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService) (string, error) {
+	customPolicy := kubeSvc.Spec.Security.Audit.CustomPolicy
+	if customPolicy == nil {
+		return kas.AuditPolicy(kubeSvc.Spec.Security.Audit.Profile), nil
+	}
+	configMap := &corev1.ConfigMap{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: kubeSvc.Namespace, Name: customPolicy.Name}, configMap); err != nil {
+		return "", fmt.Errorf("cannot get audit policy config map %s: %w", customPolicy.Name, err)
+	}
+	policy, ok := configMap.Data[kas.AuditPolicyConfigMapKey]
+	if !ok {
+		return "", fmt.Errorf("audit policy config map %s has no %s key", customPolicy.Name, kas.AuditPolicyConfigMapKey)
+	}
+	return policy, nil
+}
+
+// hostedClient returns a client of the hosted cluster of a KubernetesService,
+// which reaches its API server through the service kubeconfig.
+func (r *KubernetesServiceReconciler) hostedClient(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService) (kubernetes.Interface, error) {
+	instance := ks.InstanceName(kubeSvc)
+	kubeconfig := kas.ServiceKubeconfigSecret(ks.ControlPlaneNamespace(kubeSvc), instance)
+	if err := r.Get(ctx, client.ObjectKeyFromObject(kubeconfig), kubeconfig); err != nil {
+		return nil, fmt.Errorf("cannot get service kubeconfig: %w", err)
+	}
+	cfg, err := kas.ServiceRESTConfig(kubeconfig, instance, kubeAPIServerPort)
+	if err != nil {
+		return nil, err
+	}
+	hostedClient, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create hosted cluster client: %w", err)
+	}
+	return hostedClient, nil
+}
+
+// deleteIfExists deletes an object, ignoring the error if it does not exist.
+func (r *KubernetesServiceReconciler) deleteIfExists(ctx context.Context, object client.Object) error {
+	if err := r.Get(ctx, client.ObjectKeyFromObject(object), object); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if err := r.Delete(ctx, object); err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
 	return nil
 }
 
+// reconcilePodDisruptionBudget creates or updates a component's
+// PodDisruptionBudget when the control plane is highly available, and removes
+// it otherwise so that single replicas do not block node drains.
+func (r *KubernetesServiceReconciler) reconcilePodDisruptionBudget(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService, pdb *policyv1beta1.PodDisruptionBudget, reconcile func(*policyv1beta1.PodDisruptionBudget) error) error {
+	if !ks.IsHighlyAvailable(kubeSvc) {
+		if err := r.deleteIfExists(ctx, pdb); err != nil {
+			return fmt.Errorf("failed to delete pod disruption budget %s: %w", pdb.Name, err)
+		}
+		return nil
+	}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r, pdb, func() error {
+		ensureKSOwnerRef(kubeSvc, pdb)
+		return reconcile(pdb)
+	}); err != nil {
+		return fmt.Errorf("failed to reconcile pod disruption budget %s: %w", pdb.Name, err)
+	}
+	return nil
+}
+
+// replicasFor returns the number of replicas a component should run with
+// given the availability policy of the KubernetesService.
+func replicasFor(kubeSvc *hyperlitev1.KubernetesService, singleReplicas, haReplicas int) int {
+	if ks.IsHighlyAvailable(kubeSvc) {
+		return haReplicas
+	}
+	return singleReplicas
+}
+
+// ensureKSOwnerRef makes the KubernetesService the owner of a control plane
+// object. Objects outside of the namespace of the KubernetesService cannot
+// have it as owner, so they are labeled with it instead.
 func ensureKSOwnerRef(kubeSvc *hyperlitev1.KubernetesService, object client.Object) {
+	if object.GetNamespace() != kubeSvc.Namespace {
+		ks.SetKubernetesServiceLabels(object, kubeSvc)
+		return
+	}
 	ownerRefs := object.GetOwnerReferences()
 	newRefs := ensureOwnerRef(ownerRefs, metav1.OwnerReference{
 		APIVersion:         hyperlitev1.GroupVersion.String(),
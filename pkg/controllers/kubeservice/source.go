@@ -0,0 +1,212 @@
+package kubeservice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/etcd"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/ks"
+)
+
+// reconcileSourceRestore restores the etcd cluster of a KubernetesService
+// created from a source, one step per reconcile, and returns true once done.
+// A source KubernetesService is saved to a snapshot first, by a backup in its
+// control plane namespace writing to the snapshot storage of the copy. The
+// rest of the control plane, including its PKI and service account keys, is
+// created for the copy by the regular reconciliation.
+func (r *KubernetesServiceReconciler) reconcileSourceRestore(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService) (bool, error) {
+	if !ks.RestoringSource(kubeSvc) {
+		return true, nil
+	}
+	source := kubeSvc.Spec.Source
+	storage := kubeSvc.Spec.Lifecycle.SnapshotStorage
+	if storage == nil {
+		return false, r.setProgressCondition(ctx, kubeSvc, hyperlitev1.Restoring, "SnapshotStorageMissing", "Cannot restore etcd: snapshotStorage is not configured")
+	}
+
+	if kubeSvc.Status.Source == nil {
+		ctrl.LoggerFrom(ctx).Info("Creating control plane from source")
+		kubeSvc.Status.Source = &hyperlitev1.SourceStatus{}
+		if source.FromSnapshot != nil {
+			kubeSvc.Status.Source.Snapshot = source.FromSnapshot.Path
+		} else {
+			kubeSvc.Status.Source.Snapshot = etcd.SnapshotPath(storage, kubeSvc, fmt.Sprintf("%s-%s", etcd.CloneSnapshot, time.Now().UTC().Format(snapshotTimeFormat)))
+		}
+		ks.SetConditionByType(&kubeSvc.Status.Conditions, hyperlitev1.Restoring, corev1.ConditionTrue, "Started", "Creating the control plane from its source")
+		if err := r.Status().Update(ctx, kubeSvc); err != nil {
+			return false, fmt.Errorf("failed to update kubernetes service status: %w", err)
+		}
+	}
+	status := kubeSvc.Status.Source
+
+	if source.FromKubernetesService != nil && status.SnapshotTime == nil {
+		saved, err := r.reconcileSourceSnapshot(ctx, kubeSvc)
+		if err != nil || !saved {
+			return false, err
+		}
+		r.recorder.Eventf(kubeSvc, corev1.EventTypeNormal, "SnapshotSaved", "Saved etcd snapshot of kubernetes service %s to %s", source.FromKubernetesService.Name, status.Snapshot)
+		now := metav1.Now()
+		status.SnapshotTime = &now
+		if err := r.Status().Update(ctx, kubeSvc); err != nil {
+			return false, fmt.Errorf("failed to update kubernetes service status: %w", err)
+		}
+		if err := r.cleanupSourceSnapshot(ctx, kubeSvc); err != nil {
+			return false, err
+		}
+	}
+
+	if started, err := r.waitForEtcdOperator(ctx, kubeSvc, hyperlitev1.Restoring); err != nil || !started {
+		return false, err
+	}
+	if restored, err := r.reconcileRestore(ctx, kubeSvc, etcd.CloneSnapshot, status.Snapshot, hyperlitev1.Restoring); err != nil || !restored {
+		return false, err
+	}
+
+	now := metav1.Now()
+	status.RestoreTime = &now
+	ks.SetConditionByType(&kubeSvc.Status.Conditions, hyperlitev1.Restoring, corev1.ConditionTrue, "StartingComponents", "Waiting for the kube API server to start")
+	if err := r.Status().Update(ctx, kubeSvc); err != nil {
+		return false, fmt.Errorf("failed to update kubernetes service status: %w", err)
+	}
+	return true, nil
+}
+
+// reconcileSourceSnapshot saves a snapshot of the source KubernetesService of
+// a copy to the snapshot storage of the copy, and returns true once saved.
+func (r *KubernetesServiceReconciler) reconcileSourceSnapshot(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService) (bool, error) {
+	name := kubeSvc.Spec.Source.FromKubernetesService.Name
+	sourceKubeSvc := &hyperlitev1.KubernetesService{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: kubeSvc.Namespace, Name: name}, sourceKubeSvc); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, r.setProgressCondition(ctx, kubeSvc, hyperlitev1.Restoring, "SourceNotFound", fmt.Sprintf("Kubernetes service %s not found", name))
+		}
+		return false, fmt.Errorf("cannot get source kubernetes service: %w", err)
+	}
+	if condition := ks.GetConditionByType(sourceKubeSvc.Status.Conditions, hyperlitev1.Available); condition == nil || condition.Status != corev1.ConditionTrue {
+		return false, r.setProgressCondition(ctx, kubeSvc, hyperlitev1.Restoring, "WaitingForSource", fmt.Sprintf("Waiting for kubernetes service %s to be available", name))
+	}
+
+	// The backup runs next to the source etcd cluster and needs the storage
+	// credentials of the copy in its namespace
+	sourceNamespace := ks.ControlPlaneNamespace(sourceKubeSvc)
+	storage := kubeSvc.Spec.Lifecycle.SnapshotStorage.DeepCopy()
+	if sourceNamespace != kubeSvc.Namespace {
+		credentials := &corev1.Secret{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: kubeSvc.Namespace, Name: storage.S3.Credentials.Name}, credentials); err != nil {
+			return false, fmt.Errorf("cannot get snapshot storage credentials: %w", err)
+		}
+		secret := sourceCredentialsSecret(sourceNamespace, kubeSvc)
+		if _, err := controllerutil.CreateOrUpdate(ctx, r, secret, func() error {
+			ensureKSOwnerRef(kubeSvc, secret)
+			secret.Type = credentials.Type
+			secret.Data = credentials.Data
+			return nil
+		}); err != nil {
+			return false, fmt.Errorf("failed to copy snapshot storage credentials to the source control plane namespace: %w", err)
+		}
+		storage.S3.Credentials.Name = secret.Name
+	}
+	backup := etcd.Backup(sourceNamespace, ks.InstanceName(sourceKubeSvc), sourceSnapshotName(kubeSvc))
+	return r.reconcileBackup(ctx, kubeSvc, backup, ks.InstanceName(sourceKubeSvc), storage, kubeSvc.Status.Source.Snapshot, hyperlitev1.Restoring)
+}
+
+// cleanupSourceSnapshot removes the backup and credentials left by a copy in
+// the control plane namespace of its source KubernetesService.
+func (r *KubernetesServiceReconciler) cleanupSourceSnapshot(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService) error {
+	source := kubeSvc.Spec.Source
+	if source == nil || source.FromKubernetesService == nil {
+		return nil
+	}
+	sourceKubeSvc := &hyperlitev1.KubernetesService{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: kubeSvc.Namespace, Name: source.FromKubernetesService.Name}, sourceKubeSvc); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("cannot get source kubernetes service: %w", err)
+	}
+	sourceNamespace := ks.ControlPlaneNamespace(sourceKubeSvc)
+	if err := r.deleteIfExists(ctx, etcd.Backup(sourceNamespace, ks.InstanceName(sourceKubeSvc), sourceSnapshotName(kubeSvc))); err != nil {
+		return fmt.Errorf("failed to delete etcd backup of source: %w", err)
+	}
+	if sourceNamespace != kubeSvc.Namespace {
+		if err := r.deleteIfExists(ctx, sourceCredentialsSecret(sourceNamespace, kubeSvc)); err != nil {
+			return fmt.Errorf("failed to delete snapshot storage credentials of source: %w", err)
+		}
+	}
+	return nil
+}
+
+// reconcileRestoredCluster makes the restored hosted cluster of a copy
+// independent of its source, once its API server is available. The endpoints
+// of the kubernetes service are pointed at the API server of the copy, and
+// service account tokens signed by the source are deleted so that the
+// controller manager of the copy issues new ones.
+func (r *KubernetesServiceReconciler) reconcileRestoredCluster(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService, networking hyperlitev1.ClusterNetworkSpec) error {
+	if ks.SourceCompleted(kubeSvc) {
+		return nil
+	}
+	hostedClient, err := r.hostedClient(ctx, kubeSvc)
+	if err != nil {
+		return err
+	}
+
+	endpoints, err := hostedClient.CoreV1().Endpoints(metav1.NamespaceDefault).Get(ctx, "kubernetes", metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("cannot get kubernetes service endpoints: %w", err)
+	} else if err == nil {
+		var ports []corev1.EndpointPort
+		for _, subset := range endpoints.Subsets {
+			ports = append(ports, subset.Ports...)
+		}
+		if len(ports) == 0 {
+			ports = []corev1.EndpointPort{{Name: "https", Port: kubeAPIServerPort, Protocol: corev1.ProtocolTCP}}
+		}
+		endpoints.Subsets = []corev1.EndpointSubset{{
+			Addresses: []corev1.EndpointAddress{{IP: networking.AdvertiseAddress}},
+			Ports:     ports[:1],
+		}}
+		if _, err := hostedClient.CoreV1().Endpoints(metav1.NamespaceDefault).Update(ctx, endpoints, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("failed to update kubernetes service endpoints: %w", err)
+		}
+	}
+
+	opts := metav1.ListOptions{FieldSelector: fmt.Sprintf("type=%s", corev1.SecretTypeServiceAccountToken)}
+	if err := hostedClient.CoreV1().Secrets(metav1.NamespaceAll).DeleteCollection(ctx, metav1.DeleteOptions{}, opts); err != nil {
+		return fmt.Errorf("failed to delete service account tokens of source: %w", err)
+	}
+
+	now := metav1.Now()
+	kubeSvc.Status.Source.CompletionTime = &now
+	ks.SetConditionByType(&kubeSvc.Status.Conditions, hyperlitev1.Restoring, corev1.ConditionFalse, "Restored", "The control plane was created from its source")
+	if err := r.Status().Update(ctx, kubeSvc); err != nil {
+		return fmt.Errorf("failed to update kubernetes service status: %w", err)
+	}
+	r.recorder.Eventf(kubeSvc, corev1.EventTypeNormal, "Restored", "Created the control plane from %s", kubeSvc.Status.Source.Snapshot)
+	return nil
+}
+
+// sourceSnapshotName returns the name of the snapshot of the source of a copy,
+// which is unique among the copies of the source.
+func sourceSnapshotName(kubeSvc *hyperlitev1.KubernetesService) string {
+	return fmt.Sprintf("%s-%s", etcd.CloneSnapshot, kubeSvc.Name)
+}
+
+// sourceCredentialsSecret returns the copy of the snapshot storage credentials
+// of a KubernetesService in the control plane namespace of its source.
+func sourceCredentialsSecret(namespace string, kubeSvc *hyperlitev1.KubernetesService) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      ks.Name(ks.InstanceName(kubeSvc), "snapshot-credentials"),
+		},
+	}
+}
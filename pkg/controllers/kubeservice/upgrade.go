@@ -0,0 +1,377 @@
+package kubeservice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/etcd"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/kas"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/kcm"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/ks"
+	"github.com/openshift-hive/hypershiftlite/pkg/releaseinfo"
+)
+
+const (
+	// kubeAPIServerUpgradeTimeout is how long the API server has to become
+	// available with a new release image before the upgrade is rolled back.
+	kubeAPIServerUpgradeTimeout = 10 * time.Minute
+
+	// upgradePollInterval is how often the rollout of a release image is
+	// checked.
+	upgradePollInterval = 10 * time.Second
+
+	// releaseHistoryLimit is the number of entries kept in status.history.
+	releaseHistoryLimit = 10
+)
+
+// releaseRollout holds the release images the control plane components run
+// with. They differ while an upgrade rolls out one component after the other.
+type releaseRollout struct {
+	// current is the release image the control plane reports in its status
+	current               *releaseinfo.ReleaseImage
+	kubeAPIServer         *releaseinfo.ReleaseImage
+	kubeControllerManager *releaseinfo.ReleaseImage
+}
+
+// reconcileRelease returns the release images to run the control plane
+// components with, starting an upgrade when the release image of the
+// KubernetesService changes. An upgrade saves an etcd snapshot if snapshot
+// storage is configured, then rolls out the API server, and the controller
+// manager once the API server is available. It is rolled back to the previous
+// release image if the API server does not become available in time, or if
+// the release image is changed back while the upgrade is in progress. Other
+// changes of the release image take effect once the upgrade completes.
+// Upgrades that are not supported by the version skew policy of Kubernetes
+// are not started.
+func (r *KubernetesServiceReconciler) reconcileRelease(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService) (*releaseRollout, error) {
+	desired := kubeSvc.Spec.Release.Image
+	upgrade := kubeSvc.Status.Upgrade
+	if upgrade == nil {
+		current := ks.CurrentRelease(kubeSvc)
+		switch {
+		case current == nil:
+			// The control plane is being installed
+			releaseImage, err := r.lookupRelease(ctx, kubeSvc, desired)
+			if err != nil {
+				return nil, err
+			}
+			if err := r.recordInstall(ctx, kubeSvc, releaseImage); err != nil {
+				return nil, err
+			}
+			return &releaseRollout{current: releaseImage, kubeAPIServer: releaseImage, kubeControllerManager: releaseImage}, nil
+		case current.Image == desired, kubeSvc.Status.History[0].Image == desired && kubeSvc.Status.History[0].State == hyperlitev1.RolledBackUpgradeState:
+			// A release image that was rolled back is not attempted again
+			// until the release image changes
+			if err := r.clearUpgradeBlocked(ctx, kubeSvc); err != nil {
+				return nil, err
+			}
+			return r.currentRollout(ctx, kubeSvc, current.Image)
+		}
+		allowed, err := r.validateUpgrade(ctx, kubeSvc, current.Image)
+		if err != nil {
+			return nil, err
+		}
+		if !allowed {
+			return r.currentRollout(ctx, kubeSvc, current.Image)
+		}
+		if err := r.startUpgrade(ctx, kubeSvc, current.Image); err != nil {
+			return nil, err
+		}
+		upgrade = kubeSvc.Status.Upgrade
+	}
+
+	if upgrade.Phase != hyperlitev1.RollingBackUpgradePhase && desired == upgrade.PreviousImage {
+		r.recorder.Eventf(kubeSvc, corev1.EventTypeNormal, "RollingBack", "Release image changed back to %s, rolling back the upgrade", upgrade.PreviousImage)
+		if err := r.setUpgradePhase(ctx, kubeSvc, hyperlitev1.RollingBackUpgradePhase); err != nil {
+			return nil, err
+		}
+	}
+	target, err := r.lookupRelease(ctx, kubeSvc, upgrade.Image)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := r.lookupRelease(ctx, kubeSvc, upgrade.PreviousImage)
+	if err != nil {
+		return nil, err
+	}
+
+	switch upgrade.Phase {
+	case hyperlitev1.SavingSnapshotUpgradePhase:
+		snapshot := kubeSvc.Status.History[0].Snapshot
+		saved, err := r.reconcileSnapshot(ctx, kubeSvc, etcd.UpgradeSnapshot, snapshot, hyperlitev1.Upgrading)
+		if err != nil {
+			return nil, err
+		}
+		if saved {
+			r.recorder.Eventf(kubeSvc, corev1.EventTypeNormal, "SnapshotSaved", "Saved etcd snapshot to %s", snapshot)
+			if err := r.deleteIfExists(ctx, etcd.Backup(ks.ControlPlaneNamespace(kubeSvc), ks.InstanceName(kubeSvc), etcd.UpgradeSnapshot)); err != nil {
+				return nil, fmt.Errorf("failed to delete etcd backup: %w", err)
+			}
+			if err := r.setUpgradePhase(ctx, kubeSvc, hyperlitev1.KubeAPIServerUpgradePhase); err != nil {
+				return nil, err
+			}
+			return &releaseRollout{current: previous, kubeAPIServer: target, kubeControllerManager: previous}, nil
+		}
+		return &releaseRollout{current: previous, kubeAPIServer: previous, kubeControllerManager: previous}, nil
+	case hyperlitev1.KubeAPIServerUpgradePhase:
+		return &releaseRollout{current: previous, kubeAPIServer: target, kubeControllerManager: previous}, nil
+	case hyperlitev1.KubeControllerManagerUpgradePhase:
+		return &releaseRollout{current: previous, kubeAPIServer: target, kubeControllerManager: target}, nil
+	default:
+		return &releaseRollout{current: previous, kubeAPIServer: previous, kubeControllerManager: previous}, nil
+	}
+}
+
+// currentRollout returns the release images to keep running all control plane
+// components with the current release image.
+func (r *KubernetesServiceReconciler) currentRollout(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService, current string) (*releaseRollout, error) {
+	releaseImage, err := r.lookupRelease(ctx, kubeSvc, current)
+	if err != nil {
+		return nil, err
+	}
+	return &releaseRollout{current: releaseImage, kubeAPIServer: releaseImage, kubeControllerManager: releaseImage}, nil
+}
+
+// validateUpgrade checks the Kubernetes versions of the current release image
+// and the release image of the KubernetesService against the version skew
+// policy of Kubernetes. It returns false and reports the reason in the
+// UpgradeBlocked condition if the upgrade is not supported.
+func (r *KubernetesServiceReconciler) validateUpgrade(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService, current string) (bool, error) {
+	log := ctrl.LoggerFrom(ctx)
+	image := kubeSvc.Spec.Release.Image
+	from, err := r.lookupRelease(ctx, kubeSvc, current)
+	if err != nil {
+		return false, err
+	}
+	to, err := r.lookupRelease(ctx, kubeSvc, image)
+	if err != nil {
+		return false, err
+	}
+	// Versions that cannot be read are unknown, which blocks the upgrade
+	// unless it is forced
+	fromVersions, err := from.ComponentVersions()
+	if err != nil {
+		log.Info("Cannot read component versions of the current release image", "image", current, "error", err.Error())
+	}
+	toVersions, err := to.ComponentVersions()
+	if err != nil {
+		log.Info("Cannot read component versions of the target release image", "image", image, "error", err.Error())
+	}
+	reason, err := ks.ValidateUpgrade(fromVersions, toVersions, ks.UpgradeForced(kubeSvc, image))
+	if err != nil {
+		message := fmt.Sprintf("Upgrade from %s to %s is blocked: %v", current, image, err)
+		if condition := ks.GetConditionByType(kubeSvc.Status.Conditions, hyperlitev1.UpgradeBlocked); condition == nil || condition.Status != corev1.ConditionTrue || condition.Message != message {
+			r.recorder.Event(kubeSvc, corev1.EventTypeWarning, "UpgradeBlocked", message)
+		}
+		return false, r.setProgressCondition(ctx, kubeSvc, hyperlitev1.UpgradeBlocked, reason, message)
+	}
+	return true, r.clearUpgradeBlocked(ctx, kubeSvc)
+}
+
+// clearUpgradeBlocked sets the UpgradeBlocked condition to false once the
+// release image of the KubernetesService no longer requires an unsupported
+// upgrade.
+func (r *KubernetesServiceReconciler) clearUpgradeBlocked(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService) error {
+	if condition := ks.GetConditionByType(kubeSvc.Status.Conditions, hyperlitev1.UpgradeBlocked); condition == nil || condition.Status != corev1.ConditionTrue {
+		return nil
+	}
+	ks.SetConditionByType(&kubeSvc.Status.Conditions, hyperlitev1.UpgradeBlocked, corev1.ConditionFalse, "AsExpected", "The release image can be rolled out")
+	if err := r.Status().Update(ctx, kubeSvc); err != nil {
+		return fmt.Errorf("failed to update kubernetes service status: %w", err)
+	}
+	return nil
+}
+
+// lookupRelease returns the metadata of a release image.
+func (r *KubernetesServiceReconciler) lookupRelease(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService, image string) (*releaseinfo.ReleaseImage, error) {
+	return r.getReleaseImage(ctx, ks.ControlPlaneNamespace(kubeSvc), image, kubeSvc.Spec.Release.PullSecret.Name)
+}
+
+// recordInstall records the release image a control plane is installed with
+// in its history.
+func (r *KubernetesServiceReconciler) recordInstall(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService, releaseImage *releaseinfo.ReleaseImage) error {
+	image := kubeSvc.Spec.Release.Image
+	history := kubeSvc.Status.History
+	if len(history) > 0 && history[0].State == hyperlitev1.PartialUpgradeState {
+		if history[0].Image == image {
+			return nil
+		}
+		// The release image changed before the installation completed
+		history[0].Image = image
+		history[0].Version = releaseImage.Version()
+	} else {
+		kubeSvc.Status.History = append([]hyperlitev1.ReleaseHistory{{
+			State:       hyperlitev1.PartialUpgradeState,
+			Version:     releaseImage.Version(),
+			Image:       image,
+			StartedTime: metav1.Now(),
+		}}, history...)
+	}
+	if err := r.Status().Update(ctx, kubeSvc); err != nil {
+		return fmt.Errorf("failed to update release history: %w", err)
+	}
+	return nil
+}
+
+// startUpgrade starts upgrading the control plane from the current release
+// image to the release image of the KubernetesService.
+func (r *KubernetesServiceReconciler) startUpgrade(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService, current string) error {
+	image := kubeSvc.Spec.Release.Image
+	target, err := r.lookupRelease(ctx, kubeSvc, image)
+	if err != nil {
+		return err
+	}
+	ctrl.LoggerFrom(ctx).Info("Upgrading control plane", "from", current, "to", image)
+	now := metav1.Now()
+	entry := hyperlitev1.ReleaseHistory{
+		State:       hyperlitev1.PartialUpgradeState,
+		Version:     target.Version(),
+		Image:       image,
+		StartedTime: now,
+	}
+	phase := hyperlitev1.KubeAPIServerUpgradePhase
+	if storage := kubeSvc.Spec.Lifecycle.SnapshotStorage; storage != nil {
+		entry.Snapshot = etcd.SnapshotPath(storage, kubeSvc, fmt.Sprintf("%s-%s", etcd.UpgradeSnapshot, now.UTC().Format(snapshotTimeFormat)))
+		phase = hyperlitev1.SavingSnapshotUpgradePhase
+	}
+	kubeSvc.Status.History = append([]hyperlitev1.ReleaseHistory{entry}, kubeSvc.Status.History...)
+	if len(kubeSvc.Status.History) > releaseHistoryLimit {
+		kubeSvc.Status.History = kubeSvc.Status.History[:releaseHistoryLimit]
+	}
+	kubeSvc.Status.Upgrade = &hyperlitev1.UpgradeStatus{
+		Image:         image,
+		PreviousImage: current,
+	}
+	r.recorder.Eventf(kubeSvc, corev1.EventTypeNormal, "UpgradeStarted", "Upgrading from %s to %s", current, image)
+	return r.setUpgradePhase(ctx, kubeSvc, phase)
+}
+
+// setUpgradePhase moves an upgrade to the given phase and reports it in the
+// Upgrading condition.
+func (r *KubernetesServiceReconciler) setUpgradePhase(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService, phase hyperlitev1.UpgradePhase) error {
+	upgrade := kubeSvc.Status.Upgrade
+	upgrade.Phase = phase
+	upgrade.PhaseStartTime = metav1.Now()
+	var message string
+	switch phase {
+	case hyperlitev1.SavingSnapshotUpgradePhase:
+		message = fmt.Sprintf("Saving etcd snapshot before upgrading to %s", upgrade.Image)
+	case hyperlitev1.KubeAPIServerUpgradePhase:
+		message = fmt.Sprintf("Rolling out the kube API server of %s", upgrade.Image)
+	case hyperlitev1.KubeControllerManagerUpgradePhase:
+		message = fmt.Sprintf("Rolling out the kube controller manager of %s", upgrade.Image)
+	case hyperlitev1.RollingBackUpgradePhase:
+		message = fmt.Sprintf("Rolling back to %s", upgrade.PreviousImage)
+	}
+	ks.SetConditionByType(&kubeSvc.Status.Conditions, hyperlitev1.Upgrading, corev1.ConditionTrue, string(phase), message)
+	if err := r.Status().Update(ctx, kubeSvc); err != nil {
+		return fmt.Errorf("failed to update upgrade status: %w", err)
+	}
+	return nil
+}
+
+// reconcileKubeAPIServerUpgrade moves an upgrade on to the controller manager
+// once the API server runs the new release image and is available, and rolls
+// it back if the API server does not become available in time. It returns a
+// non-zero result while the API server is being rolled out.
+func (r *KubernetesServiceReconciler) reconcileKubeAPIServerUpgrade(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService, rollout *releaseRollout) (ctrl.Result, error) {
+	upgrade := kubeSvc.Status.Upgrade
+	if upgrade == nil || upgrade.Phase != hyperlitev1.KubeAPIServerUpgradePhase {
+		return ctrl.Result{}, nil
+	}
+	rolledOut, err := r.deploymentRolledOut(ctx, kas.Deployment(ks.ControlPlaneNamespace(kubeSvc), ks.InstanceName(kubeSvc)), rollout.kubeAPIServer)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if available := ks.GetConditionByType(kubeSvc.Status.Conditions, hyperlitev1.KubeAPIServerAvailable); rolledOut && available != nil && available.Status == corev1.ConditionTrue {
+		if err := r.setUpgradePhase(ctx, kubeSvc, hyperlitev1.KubeControllerManagerUpgradePhase); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{Requeue: true}, nil
+	}
+	remaining := kubeAPIServerUpgradeTimeout - time.Since(upgrade.PhaseStartTime.Time)
+	if remaining <= 0 {
+		r.recorder.Eventf(kubeSvc, corev1.EventTypeWarning, "UpgradeFailed", "Kube API server of %s did not become available within %s, rolling back to %s", upgrade.Image, kubeAPIServerUpgradeTimeout, upgrade.PreviousImage)
+		if err := r.setUpgradePhase(ctx, kubeSvc, hyperlitev1.RollingBackUpgradePhase); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{Requeue: true}, nil
+	}
+	if remaining > upgradePollInterval {
+		remaining = upgradePollInterval
+	}
+	return ctrl.Result{RequeueAfter: remaining}, nil
+}
+
+// reconcileUpgradeCompletion records the release image in the history once
+// every component runs it, which completes an installation, an upgrade or a
+// rollback. It returns a non-zero result until then.
+func (r *KubernetesServiceReconciler) reconcileUpgradeCompletion(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService, rollout *releaseRollout) (ctrl.Result, error) {
+	history := kubeSvc.Status.History
+	upgrade := kubeSvc.Status.Upgrade
+	if len(history) == 0 || history[0].State != hyperlitev1.PartialUpgradeState {
+		return ctrl.Result{}, nil
+	}
+	if upgrade != nil && upgrade.Phase != hyperlitev1.KubeControllerManagerUpgradePhase && upgrade.Phase != hyperlitev1.RollingBackUpgradePhase {
+		return ctrl.Result{}, nil
+	}
+	namespace, instance := ks.ControlPlaneNamespace(kubeSvc), ks.InstanceName(kubeSvc)
+	kasRolledOut, err := r.deploymentRolledOut(ctx, kas.Deployment(namespace, instance), rollout.kubeAPIServer)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	kcmRolledOut, err := r.deploymentRolledOut(ctx, kcm.Deployment(namespace, instance), rollout.kubeControllerManager)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !kasRolledOut || !kcmRolledOut {
+		return ctrl.Result{RequeueAfter: upgradePollInterval}, nil
+	}
+
+	now := metav1.Now()
+	history[0].CompletionTime = &now
+	switch {
+	case upgrade == nil:
+		history[0].State = hyperlitev1.CompletedUpgradeState
+	case upgrade.Phase == hyperlitev1.KubeControllerManagerUpgradePhase:
+		history[0].State = hyperlitev1.CompletedUpgradeState
+		ks.SetConditionByType(&kubeSvc.Status.Conditions, hyperlitev1.Upgrading, corev1.ConditionFalse, "Completed", fmt.Sprintf("Upgraded to %s", upgrade.Image))
+		r.recorder.Eventf(kubeSvc, corev1.EventTypeNormal, "Upgraded", "Upgraded from %s to %s", upgrade.PreviousImage, upgrade.Image)
+	default:
+		history[0].State = hyperlitev1.RolledBackUpgradeState
+		ks.SetConditionByType(&kubeSvc.Status.Conditions, hyperlitev1.Upgrading, corev1.ConditionFalse, "RolledBack", fmt.Sprintf("Upgrade to %s was rolled back to %s", upgrade.Image, upgrade.PreviousImage))
+		r.recorder.Eventf(kubeSvc, corev1.EventTypeWarning, "RolledBack", "Rolled back from %s to %s", upgrade.Image, upgrade.PreviousImage)
+	}
+	if upgrade != nil {
+		// A snapshot interrupted by a rollback is not needed anymore
+		if err := r.deleteIfExists(ctx, etcd.Backup(namespace, instance, etcd.UpgradeSnapshot)); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to delete etcd backup: %w", err)
+		}
+	}
+	kubeSvc.Status.Upgrade = nil
+	if err := r.Status().Update(ctx, kubeSvc); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update release history: %w", err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// deploymentRolledOut returns true if all replicas of a control plane
+// deployment run the hyperkube image of the given release and are available.
+func (r *KubernetesServiceReconciler) deploymentRolledOut(ctx context.Context, deployment *appsv1.Deployment, releaseImage *releaseinfo.ReleaseImage) (bool, error) {
+	if err := r.Get(ctx, client.ObjectKeyFromObject(deployment), deployment); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("cannot get deployment %s: %w", deployment.Name, err)
+	}
+	return ks.DeploymentRunsImage(deployment, releaseImage.ComponentImages()["hyperkube"]) && ks.DeploymentRolledOut(deployment), nil
+}
@@ -0,0 +1,247 @@
+package kubeservice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/kas"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/kcm"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/ks"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/pki"
+)
+
+// validateKubernetesService verifies the configuration of a KubernetesService.
+// It returns the reason to report in the ValidConfiguration condition along
+// with any validation error.
+func (r *KubernetesServiceReconciler) validateKubernetesService(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService, networking hyperlitev1.ClusterNetworkSpec) (string, error) {
+	if err := ks.ValidateInstanceName(kubeSvc); err != nil {
+		return "InvalidName", err
+	}
+	if err := validateNetworking(kubeSvc, networking); err != nil {
+		return "InvalidNetworking", err
+	}
+	if err := ks.ValidateComponents(kubeSvc.Spec.Components); err != nil {
+		return "InvalidComponents", err
+	}
+	if err := ks.ValidatePublishing(kubeSvc.Spec.Networking.APIServerPublishing); err != nil {
+		return "InvalidAPIServerPublishing", err
+	}
+	if err := r.validateServingCerts(ctx, kubeSvc, networking); err != nil {
+		return "InvalidServingCerts", err
+	}
+	if err := kas.ValidateFeatureGates(kubeSvc.Spec.Components.FeatureGates); err != nil {
+		return "InvalidFeatureGates", err
+	}
+	if err := kas.ValidateExtraArgs(kubeSvc.Spec.Components.KubeAPIServer.ExtraArgs); err != nil {
+		return "InvalidExtraArgs", fmt.Errorf("invalid components.kubeAPIServer.extraArgs: %w", err)
+	}
+	if err := kas.ValidateAdmission(kubeSvc.Spec.Components.KubeAPIServer.Admission); err != nil {
+		return "InvalidAdmission", err
+	}
+	if err := kcm.ValidateExtraArgs(kubeSvc.Spec.Components.KubeControllerManager.ExtraArgs); err != nil {
+		return "InvalidExtraArgs", fmt.Errorf("invalid components.kubeControllerManager.extraArgs: %w", err)
+	}
+	if err := r.validateAudit(ctx, kubeSvc); err != nil {
+		return "InvalidAuditPolicy", err
+	}
+	if err := r.validateAuthentication(ctx, kubeSvc); err != nil {
+		return "InvalidAuthentication", err
+	}
+	if err := r.validateAuthorization(ctx, kubeSvc); err != nil {
+		return "InvalidAuthorization", err
+	}
+	if err := validateSecretEncryption(kubeSvc); err != nil {
+		return "InvalidSecretEncryption", err
+	}
+	if err := r.validateLifecycle(ctx, kubeSvc); err != nil {
+		return "InvalidLifecycle", err
+	}
+	if err := r.validateSource(ctx, kubeSvc); err != nil {
+		return "InvalidSource", err
+	}
+	if err := r.validateControlPlaneNamespace(ctx, kubeSvc); err != nil {
+		return "InvalidControlPlaneNamespace", err
+	}
+	if err := r.validateObjectOwnership(ctx, kubeSvc); err != nil {
+		return "ResourceConflict", err
+	}
+	if err := validateReferencedSecrets(kubeSvc); err != nil {
+		return "ResourceConflict", err
+	}
+	return "", nil
+}
+
+// validateNetworking checks the networking configuration of a KubernetesService
+// and ensures it has not changed since the control plane was created.
+func validateNetworking(kubeSvc *hyperlitev1.KubernetesService, networking hyperlitev1.ClusterNetworkSpec) error {
+	if err := ks.ValidateNetworking(networking); err != nil {
+		return err
+	}
+	if applied := kubeSvc.Status.Networking; applied != nil && *applied != networking {
+		return fmt.Errorf("networking cannot be changed after creation: expected serviceCIDR=%s, podCIDR=%s, clusterDomain=%s, advertiseAddress=%s",
+			applied.ServiceCIDR, applied.PodCIDR, applied.ClusterDomain, applied.AdvertiseAddress)
+	}
+	return nil
+}
+
+// validateServingCerts ensures named serving certificates reference existing
+// TLS secrets and are not served for names used within the cluster.
+func (r *KubernetesServiceReconciler) validateServingCerts(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService, networking hyperlitev1.ClusterNetworkSpec) error {
+	if err := ks.ValidateServingCerts(kubeSvc.Spec.Components.KubeAPIServer.ServingCerts, networking.ClusterDomain); err != nil {
+		return err
+	}
+	for _, namedCert := range kubeSvc.Spec.Components.KubeAPIServer.ServingCerts {
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: kubeSvc.Namespace, Name: namedCert.ServingCertificate.Name}, secret); err != nil {
+			if apierrors.IsNotFound(err) {
+				return fmt.Errorf("serving certificate secret %s not found", namedCert.ServingCertificate.Name)
+			}
+			return fmt.Errorf("cannot get serving certificate secret %s: %w", namedCert.ServingCertificate.Name, err)
+		}
+		if secret.Type != corev1.SecretTypeTLS {
+			return fmt.Errorf("serving certificate secret %s must be of type %s", secret.Name, corev1.SecretTypeTLS)
+		}
+	}
+	return nil
+}
+
+// validateAudit ensures a custom audit policy exists and can be loaded by the
+// API server before it is rolled out.
+func (r *KubernetesServiceReconciler) validateAudit(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService) error {
+	if kubeSvc.Spec.Security.Audit.CustomPolicy == nil {
+		return nil
+	}
+	policy, err := r.auditPolicy(ctx, kubeSvc)
+	if err != nil {
+		return err
+	}
+	return kas.ValidateAuditPolicy(policy)
+}
+
+// validateAuthentication verifies the authenticators of the API server and
+// ensures the secrets they reference exist.
+func (r *KubernetesServiceReconciler) validateAuthentication(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService) error {
+	authentication := kubeSvc.Spec.Security.Authentication
+	if err := kas.ValidateAuthentication(authentication); err != nil {
+		return err
+	}
+	if oidc := authentication.OIDC; oidc != nil && oidc.CA != nil {
+		if err := r.validateSecretKeys(ctx, kubeSvc.Namespace, oidc.CA.Name, pki.CASignerCertMapKey); err != nil {
+			return fmt.Errorf("invalid OIDC CA: %w", err)
+		}
+	}
+	if webhook := authentication.Webhook; webhook != nil {
+		if err := r.validateSecretKeys(ctx, kubeSvc.Namespace, webhook.KubeConfig.Name, kas.KubeconfigKey); err != nil {
+			return fmt.Errorf("invalid authentication webhook kubeconfig: %w", err)
+		}
+	}
+	return nil
+}
+
+// validateAuthorization verifies the authorizers of the API server and ensures
+// the secrets they reference exist.
+func (r *KubernetesServiceReconciler) validateAuthorization(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService) error {
+	authorization := kubeSvc.Spec.Security.Authorization
+	if err := kas.ValidateAuthorization(authorization); err != nil {
+		return err
+	}
+	if webhook := authorization.Webhook; webhook != nil {
+		if err := r.validateSecretKeys(ctx, kubeSvc.Namespace, webhook.KubeConfig.Name, kas.KubeconfigKey); err != nil {
+			return fmt.Errorf("invalid authorization webhook kubeconfig: %w", err)
+		}
+	}
+	return nil
+}
+
+// validateLifecycle verifies the lifecycle settings of a KubernetesService and
+// ensures the credentials of the snapshot storage exist.
+func (r *KubernetesServiceReconciler) validateLifecycle(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService) error {
+	if err := ks.ValidateLifecycle(kubeSvc.Spec.Lifecycle); err != nil {
+		return err
+	}
+	if _, _, err := ks.ExpiresAt(kubeSvc); err != nil {
+		return err
+	}
+	if storage := kubeSvc.Spec.Lifecycle.SnapshotStorage; storage != nil {
+		if err := r.validateSecretKeys(ctx, kubeSvc.Namespace, storage.S3.Credentials.Name, "credentials"); err != nil {
+			return fmt.Errorf("invalid snapshot storage credentials: %w", err)
+		}
+	}
+	return nil
+}
+
+// validateSource verifies the source of a KubernetesService. A
+// KubernetesService that encrypts secrets cannot be copied, as the copy does
+// not have its encryption keys.
+func (r *KubernetesServiceReconciler) validateSource(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService) error {
+	if err := ks.ValidateSource(kubeSvc); err != nil {
+		return err
+	}
+	source := kubeSvc.Spec.Source
+	if source == nil || source.FromKubernetesService == nil || (kubeSvc.Status.Source != nil && kubeSvc.Status.Source.SnapshotTime != nil) {
+		return nil
+	}
+	sourceKubeSvc := &hyperlitev1.KubernetesService{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: kubeSvc.Namespace, Name: source.FromKubernetesService.Name}, sourceKubeSvc); err != nil {
+		if apierrors.IsNotFound(err) {
+			// Reported while waiting for the source
+			return nil
+		}
+		return fmt.Errorf("cannot get source kubernetes service: %w", err)
+	}
+	if sourceKubeSvc.Spec.Security.SecretEncryption != nil || sourceKubeSvc.Status.SecretEncryption != nil {
+		return fmt.Errorf("kubernetes service %s encrypts secrets and cannot be copied", sourceKubeSvc.Name)
+	}
+	return nil
+}
+
+// validateSecretKeys ensures a secret exists and holds the given keys.
+func (r *KubernetesServiceReconciler) validateSecretKeys(ctx context.Context, namespace, name string, keys ...string) error {
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return fmt.Errorf("secret %s not found", name)
+		}
+		return fmt.Errorf("cannot get secret %s: %w", name, err)
+	}
+	for _, key := range keys {
+		if _, ok := secret.Data[key]; !ok {
+			return fmt.Errorf("secret %s has no %s key", name, key)
+		}
+	}
+	return nil
+}
+
+// validateSecretEncryption ensures secret encryption is not disabled once
+// enabled, as secrets stored in etcd would become unreadable, and that a KMS
+// plugin is specified as long as KMS keys are in use.
+func validateSecretEncryption(kubeSvc *hyperlitev1.KubernetesService) error {
+	spec := kubeSvc.Spec.Security.SecretEncryption
+	if spec == nil {
+		if status := kubeSvc.Status.SecretEncryption; status != nil && len(status.Keys) > 0 {
+			return fmt.Errorf("security.secretEncryption cannot be removed once enabled")
+		}
+		return nil
+	}
+	if spec.RotationInterval != nil && spec.RotationInterval.Duration < time.Hour {
+		return fmt.Errorf("security.secretEncryption rotationInterval must be at least 1h")
+	}
+	usesKMS := spec.Type == hyperlitev1.KMSEncryption
+	if status := kubeSvc.Status.SecretEncryption; status != nil {
+		for _, key := range status.Keys {
+			if key.Type == hyperlitev1.KMSEncryption {
+				usesKMS = true
+			}
+		}
+	}
+	if usesKMS && (spec.KMS == nil || spec.KMS.Image == "") {
+		return fmt.Errorf("security.secretEncryption kms.image is required while KMS encryption keys are in use")
+	}
+	return nil
+}
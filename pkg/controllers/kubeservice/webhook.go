@@ -0,0 +1,287 @@
+package kubeservice
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/kas"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/kcm"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/ks"
+	"github.com/openshift-hive/hypershiftlite/pkg/releaseinfo"
+)
+
+const (
+	MutatingWebhookPath   = "/mutate-kubernetesservice"
+	ValidatingWebhookPath = "/validate-kubernetesservice"
+)
+
+// KubernetesServiceWebhook defaults KubernetesServices and rejects invalid
+// ones when they are created or updated, so that configuration errors are
+// reported to the user instead of surfacing during reconciliation. Checks that
+// depend on objects the user may create later, such as audit policies, are
+// left to the reconciler.
+type KubernetesServiceWebhook struct {
+	Client client.Client
+
+	decoder *admission.Decoder
+}
+
+func (w *KubernetesServiceWebhook) SetupWithManager(mgr ctrl.Manager) error {
+	decoder, err := admission.NewDecoder(mgr.GetScheme())
+	if err != nil {
+		return fmt.Errorf("failed to create admission decoder: %w", err)
+	}
+	w.decoder = decoder
+	server := mgr.GetWebhookServer()
+	server.Register(MutatingWebhookPath, &webhook.Admission{Handler: admission.HandlerFunc(w.mutate)})
+	server.Register(ValidatingWebhookPath, &webhook.Admission{Handler: admission.HandlerFunc(w.validate)})
+	return nil
+}
+
+func (w *KubernetesServiceWebhook) mutate(ctx context.Context, req admission.Request) admission.Response {
+	kubeSvc := &hyperlitev1.KubernetesService{}
+	if err := w.decoder.Decode(req, kubeSvc); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	DefaultKubernetesService(kubeSvc)
+	defaulted, err := json.Marshal(kubeSvc)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, defaulted)
+}
+
+func (w *KubernetesServiceWebhook) validate(ctx context.Context, req admission.Request) admission.Response {
+	kubeSvc := &hyperlitev1.KubernetesService{}
+	if err := w.decoder.Decode(req, kubeSvc); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	// Objects being deleted only have their finalizers removed
+	if !kubeSvc.DeletionTimestamp.IsZero() {
+		return admission.Allowed("")
+	}
+	var errs field.ErrorList
+	pullSecretChanged := true
+	if req.Operation == admissionv1.Update {
+		old := &hyperlitev1.KubernetesService{}
+		if err := w.decoder.DecodeRaw(req.OldObject, old); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		// The spec is only validated when it changes, so that objects
+		// created before a check was added can still be paused, annotated
+		// or have their finalizers updated. The old object is defaulted
+		// like the new one was.
+		DefaultKubernetesService(old)
+		if !equality.Semantic.DeepEqual(kubeSvc.Spec, old.Spec) {
+			errs = validateKubernetesService(kubeSvc)
+		} else {
+			errs = validateAnnotations(kubeSvc, old)
+		}
+		errs = append(errs, validateKubernetesServiceUpdate(kubeSvc, old)...)
+		pullSecretChanged = kubeSvc.Spec.Release.PullSecret != old.Spec.Release.PullSecret
+	} else {
+		errs = validateKubernetesService(kubeSvc)
+	}
+	// The pull secret is only needed to look up a new release image, so
+	// existing KubernetesServices remain editable if it is removed
+	if pullSecretChanged {
+		errs = append(errs, w.validatePullSecret(ctx, req.Namespace, kubeSvc.Spec.Release.PullSecret.Name, field.NewPath("spec", "release", "pullSecret", "name"))...)
+	}
+	if len(errs) > 0 {
+		status := apierrors.NewInvalid(hyperlitev1.GroupVersion.WithKind("KubernetesService").GroupKind(), kubeSvc.Name, errs).Status()
+		return admission.Response{
+			AdmissionResponse: admissionv1.AdmissionResponse{
+				Allowed: false,
+				Result:  &status,
+			},
+		}
+	}
+	return admission.Allowed("")
+}
+
+// DefaultKubernetesService fills in the defaults of unset fields of a
+// KubernetesService.
+func DefaultKubernetesService(kubeSvc *hyperlitev1.KubernetesService) {
+	if kubeSvc.Spec.ControlPlaneNamespace == "" {
+		kubeSvc.Spec.ControlPlaneNamespace = hyperlitev1.SharedNamespace
+	}
+	kubeSvc.Spec.Networking.ClusterNetworkSpec = ks.Networking(kubeSvc)
+	if kubeSvc.Spec.Networking.APIServerPublishing.Type == "" {
+		kubeSvc.Spec.Networking.APIServerPublishing.Type = hyperlitev1.ClusterIPPublishing
+	}
+	if kubeSvc.Spec.Components.AvailabilityPolicy == "" {
+		kubeSvc.Spec.Components.AvailabilityPolicy = hyperlitev1.SingleReplica
+	}
+	if kubeSvc.Spec.Components.KubeAPIServer.Admission.Profile == "" {
+		kubeSvc.Spec.Components.KubeAPIServer.Admission.Profile = hyperlitev1.OpenShiftAdmissionProfile
+	}
+	if kubeSvc.Spec.Security.Audit.Profile == "" {
+		kubeSvc.Spec.Security.Audit.Profile = hyperlitev1.DefaultAuditProfile
+	}
+	if encryption := kubeSvc.Spec.Security.SecretEncryption; encryption != nil && encryption.Type == "" {
+		encryption.Type = hyperlitev1.AESCBCEncryption
+	}
+	if kubeSvc.Spec.Lifecycle.DeletionPolicy == "" {
+		kubeSvc.Spec.Lifecycle.DeletionPolicy = hyperlitev1.DeleteDeletionPolicy
+	}
+	if kubeSvc.Spec.Lifecycle.PowerState == "" {
+		kubeSvc.Spec.Lifecycle.PowerState = hyperlitev1.RunningPowerState
+	}
+}
+
+// validateKubernetesService verifies the settings of a KubernetesService that
+// do not depend on other objects.
+func validateKubernetesService(kubeSvc *hyperlitev1.KubernetesService) field.ErrorList {
+	errs := validateAnnotations(kubeSvc, nil)
+	specPath := field.NewPath("spec")
+
+	if err := ks.ValidateInstanceName(kubeSvc); err != nil {
+		errs = append(errs, field.Invalid(field.NewPath("metadata", "name"), kubeSvc.Name, err.Error()))
+	}
+
+	releasePath := specPath.Child("release")
+	if kubeSvc.Spec.Release.Image == "" {
+		errs = append(errs, field.Required(releasePath.Child("image"), "release image is required"))
+	} else if err := releaseinfo.ValidatePullSpec(kubeSvc.Spec.Release.Image); err != nil {
+		errs = append(errs, field.Invalid(releasePath.Child("image"), kubeSvc.Spec.Release.Image, err.Error()))
+	}
+
+	if err := ks.ValidateControlPlaneNamespace(kubeSvc); err != nil {
+		errs = append(errs, field.Invalid(specPath.Child("controlPlaneNamespace"), kubeSvc.Spec.ControlPlaneNamespace, err.Error()))
+	} else if err := validateReferencedSecrets(kubeSvc); err != nil {
+		errs = append(errs, field.Forbidden(specPath, err.Error()))
+	}
+
+	networkingPath := specPath.Child("networking")
+	networking := ks.Networking(kubeSvc)
+	if err := ks.ValidateNetworking(networking); err != nil {
+		errs = append(errs, field.Invalid(networkingPath, networking, err.Error()))
+	}
+	if err := ks.ValidatePublishing(kubeSvc.Spec.Networking.APIServerPublishing); err != nil {
+		errs = append(errs, field.Invalid(networkingPath.Child("apiServerPublishing"), kubeSvc.Spec.Networking.APIServerPublishing, err.Error()))
+	}
+
+	componentsPath := specPath.Child("components")
+	components := kubeSvc.Spec.Components
+	if err := ks.ValidateComponents(components); err != nil {
+		errs = append(errs, field.Invalid(componentsPath.Child("etcd"), components.Etcd, err.Error()))
+	}
+	if err := kas.ValidateFeatureGates(components.FeatureGates); err != nil {
+		errs = append(errs, field.Invalid(componentsPath.Child("featureGates"), components.FeatureGates, err.Error()))
+	}
+	kasPath := componentsPath.Child("kubeAPIServer")
+	if err := ks.ValidateServingCerts(components.KubeAPIServer.ServingCerts, networking.ClusterDomain); err != nil {
+		errs = append(errs, field.Invalid(kasPath.Child("servingCerts"), components.KubeAPIServer.ServingCerts, err.Error()))
+	}
+	if err := kas.ValidateExtraArgs(components.KubeAPIServer.ExtraArgs); err != nil {
+		errs = append(errs, field.Invalid(kasPath.Child("extraArgs"), components.KubeAPIServer.ExtraArgs, err.Error()))
+	}
+	if err := kas.ValidateAdmission(components.KubeAPIServer.Admission); err != nil {
+		errs = append(errs, field.Invalid(kasPath.Child("admission"), components.KubeAPIServer.Admission, err.Error()))
+	}
+	if err := kcm.ValidateExtraArgs(components.KubeControllerManager.ExtraArgs); err != nil {
+		errs = append(errs, field.Invalid(componentsPath.Child("kubeControllerManager", "extraArgs"), components.KubeControllerManager.ExtraArgs, err.Error()))
+	}
+
+	securityPath := specPath.Child("security")
+	if err := kas.ValidateAuthentication(kubeSvc.Spec.Security.Authentication); err != nil {
+		errs = append(errs, field.Invalid(securityPath.Child("authentication"), kubeSvc.Spec.Security.Authentication, err.Error()))
+	}
+	if err := kas.ValidateAuthorization(kubeSvc.Spec.Security.Authorization); err != nil {
+		errs = append(errs, field.Invalid(securityPath.Child("authorization"), kubeSvc.Spec.Security.Authorization, err.Error()))
+	}
+
+	if err := ks.ValidateLifecycle(kubeSvc.Spec.Lifecycle); err != nil {
+		errs = append(errs, field.Invalid(specPath.Child("lifecycle"), kubeSvc.Spec.Lifecycle, err.Error()))
+	}
+	if err := ks.ValidateSource(kubeSvc); err != nil {
+		errs = append(errs, field.Invalid(specPath.Child("source"), kubeSvc.Spec.Source, err.Error()))
+	}
+	return errs
+}
+
+// validateAnnotations verifies the annotations of a KubernetesService that
+// change its behavior. When an old object is given, only annotations whose
+// value changed are verified.
+func validateAnnotations(kubeSvc, old *hyperlitev1.KubernetesService) field.ErrorList {
+	var errs field.ErrorList
+	annotationsPath := field.NewPath("metadata", "annotations")
+	changed := func(key string) (string, bool) {
+		value, ok := kubeSvc.Annotations[key]
+		if !ok {
+			return "", false
+		}
+		if old != nil {
+			if oldValue, ok := old.Annotations[key]; ok && oldValue == value {
+				return "", false
+			}
+		}
+		return value, true
+	}
+	if value, ok := changed(ks.PausedUntilAnnotation); ok {
+		if _, _, err := ks.ParsePausedUntil(value); err != nil {
+			errs = append(errs, field.Invalid(annotationsPath.Key(ks.PausedUntilAnnotation), value, err.Error()))
+		}
+	}
+	if value, ok := changed(ks.LifetimeExtensionAnnotation); ok {
+		if _, err := ks.ParseLifetimeExtension(value); err != nil {
+			errs = append(errs, field.Invalid(annotationsPath.Key(ks.LifetimeExtensionAnnotation), value, err.Error()))
+		}
+	}
+	return errs
+}
+
+// validatePullSecret ensures the pull secret exists and holds registry
+// credentials.
+func (w *KubernetesServiceWebhook) validatePullSecret(ctx context.Context, namespace, name string, fldPath *field.Path) field.ErrorList {
+	if name == "" {
+		return field.ErrorList{field.Required(fldPath, "pull secret is required")}
+	}
+	secret := &corev1.Secret{}
+	if err := w.Client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return field.ErrorList{field.NotFound(fldPath, name)}
+		}
+		return field.ErrorList{field.InternalError(fldPath, fmt.Errorf("cannot get pull secret: %w", err))}
+	}
+	switch secret.Type {
+	case corev1.SecretTypeDockerConfigJson, corev1.SecretTypeDockercfg:
+		return nil
+	default:
+		return field.ErrorList{field.Invalid(fldPath, name, fmt.Sprintf("pull secret must be of type %s or %s", corev1.SecretTypeDockerConfigJson, corev1.SecretTypeDockercfg))}
+	}
+}
+
+// validateKubernetesServiceUpdate rejects changes to settings that cannot be
+// changed once the control plane exists.
+func validateKubernetesServiceUpdate(kubeSvc, old *hyperlitev1.KubernetesService) field.ErrorList {
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+	if networking := ks.Networking(kubeSvc); networking != ks.Networking(old) {
+		errs = append(errs, field.Invalid(specPath.Child("networking"), networking, "networking cannot be changed after creation"))
+	}
+	if ks.ControlPlaneNamespace(kubeSvc) != ks.ControlPlaneNamespace(old) {
+		errs = append(errs, field.Invalid(specPath.Child("controlPlaneNamespace"), kubeSvc.Spec.ControlPlaneNamespace, "controlPlaneNamespace cannot be changed after creation"))
+	}
+	if old.Spec.Security.SecretEncryption != nil && kubeSvc.Spec.Security.SecretEncryption == nil {
+		errs = append(errs, field.Forbidden(specPath.Child("security", "secretEncryption"), "secret encryption cannot be removed once enabled"))
+	}
+	if !equality.Semantic.DeepEqual(kubeSvc.Spec.Source, old.Spec.Source) {
+		errs = append(errs, field.Invalid(specPath.Child("source"), kubeSvc.Spec.Source, "source cannot be changed after creation"))
+	}
+	return errs
+}
@@ -0,0 +1,339 @@
+package kubeservicepool
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/kas"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/ks"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/pki"
+)
+
+// ClaimFinalizer lets the operator delete the KubernetesService bound to a
+// claim before the claim is removed.
+const ClaimFinalizer = "hypershiftlite.openshift.io/claim"
+
+// ClaimReconciler binds KubernetesServiceClaims to KubernetesServices of
+// their pool and exposes their kubeconfigs in the namespace of the claim.
+type ClaimReconciler struct {
+	client.Client
+	// Reader lists objects directly from the API server, so that a
+	// KubernetesService just bound to a claim is not missed.
+	Reader client.Reader
+
+	recorder record.EventRecorder
+}
+
+func (r *ClaimReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	_, err := ctrl.NewControllerManagedBy(mgr).
+		For(&hyperlitev1.KubernetesServiceClaim{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, &handler.EnqueueRequestForOwner{OwnerType: &hyperlitev1.KubernetesServiceClaim{}}).
+		Watches(&source.Kind{Type: &hyperlitev1.KubernetesService{}}, handler.EnqueueRequestsFromMapFunc(claimForKubernetesService)).
+		Watches(&source.Kind{Type: &hyperlitev1.KubernetesServicePool{}}, handler.EnqueueRequestsFromMapFunc(r.claimsForPool)).
+		Build(r)
+	if err != nil {
+		return fmt.Errorf("failed setting up with a controller manager %w", err)
+	}
+	r.recorder = mgr.GetEventRecorderFor("kubernetesserviceclaim-controller")
+	return nil
+}
+
+// claimForKubernetesService maps a KubernetesService to the claim it is bound
+// to.
+func claimForKubernetesService(obj client.Object) []reconcile.Request {
+	claim, ok := claimKey(obj)
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: claim}}
+}
+
+// claimsForPool maps a pool to the claims waiting for one of its
+// KubernetesServices.
+func (r *ClaimReconciler) claimsForPool(obj client.Object) []reconcile.Request {
+	claims := &hyperlitev1.KubernetesServiceClaimList{}
+	if err := r.List(context.Background(), claims); err != nil {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, claim := range claims.Items {
+		if claim.Status.KubernetesService == nil && claim.Spec.Pool == obj.GetName() && poolNamespace(&claim) == obj.GetNamespace() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&claim)})
+		}
+	}
+	return requests
+}
+
+func (r *ClaimReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx).WithValues("kubeServiceClaim", req.NamespacedName.String())
+	ctx = ctrl.LoggerInto(ctx, log)
+
+	claim := &hyperlitev1.KubernetesServiceClaim{}
+	if err := r.Get(ctx, req.NamespacedName, claim); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	kubeSvc, err := r.boundKubernetesService(ctx, claim)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Delete the bound KubernetesService with the claim
+	if !claim.DeletionTimestamp.IsZero() {
+		if !controllerutil.ContainsFinalizer(claim, ClaimFinalizer) {
+			return ctrl.Result{}, nil
+		}
+		if kubeSvc != nil {
+			log.Info("Deleting claimed KubernetesService", "name", kubeSvc.Name)
+			if err := r.Delete(ctx, kubeSvc); err != nil && !apierrors.IsNotFound(err) {
+				return ctrl.Result{}, fmt.Errorf("failed to delete kubernetes service: %w", err)
+			}
+		}
+		controllerutil.RemoveFinalizer(claim, ClaimFinalizer)
+		if err := r.Update(ctx, claim); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to remove claim finalizer: %w", err)
+		}
+		return ctrl.Result{}, nil
+	}
+	if !controllerutil.ContainsFinalizer(claim, ClaimFinalizer) {
+		controllerutil.AddFinalizer(claim, ClaimFinalizer)
+		if err := r.Update(ctx, claim); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to add claim finalizer: %w", err)
+		}
+	}
+
+	status := claim.Status.DeepCopy()
+	switch {
+	case kubeSvc == nil && status.KubernetesService != nil:
+		// A claim is only ever bound once
+		ks.SetConditionByType(&status.Conditions, hyperlitev1.Bound, corev1.ConditionFalse, "KubernetesServiceDeleted", fmt.Sprintf("KubernetesService %s/%s was deleted", status.KubernetesService.Namespace, status.KubernetesService.Name))
+		ks.SetConditionByType(&status.Conditions, hyperlitev1.Available, corev1.ConditionFalse, "KubernetesServiceDeleted", "The bound KubernetesService was deleted")
+		status.Kubeconfigs = hyperlitev1.KubeconfigSecrets{}
+	case kubeSvc == nil:
+		var reason, message string
+		kubeSvc, reason, message, err = r.bind(ctx, claim)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if kubeSvc == nil {
+			ks.SetConditionByType(&status.Conditions, hyperlitev1.Bound, corev1.ConditionFalse, reason, message)
+			ks.SetConditionByType(&status.Conditions, hyperlitev1.Available, corev1.ConditionFalse, "NotBound", "The claim is not bound to a KubernetesService")
+			break
+		}
+		fallthrough
+	default:
+		status.KubernetesService = &hyperlitev1.KubernetesServiceReference{Namespace: kubeSvc.Namespace, Name: kubeSvc.Name}
+		ks.SetConditionByType(&status.Conditions, hyperlitev1.Bound, corev1.ConditionTrue, "Bound", fmt.Sprintf("Bound to KubernetesService %s/%s", kubeSvc.Namespace, kubeSvc.Name))
+		if isAvailable(kubeSvc) {
+			ks.SetConditionByType(&status.Conditions, hyperlitev1.Available, corev1.ConditionTrue, "Available", "The bound KubernetesService is available")
+		} else {
+			ks.SetConditionByType(&status.Conditions, hyperlitev1.Available, corev1.ConditionFalse, "NotAvailable", "The bound KubernetesService is not yet available")
+		}
+		kubeconfigs, err := r.reconcileKubeconfigs(ctx, claim, kubeSvc)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		status.Kubeconfigs = kubeconfigs
+	}
+
+	if !equality.Semantic.DeepEqual(&claim.Status, status) {
+		claim.Status = *status
+		if err := r.Status().Update(ctx, claim); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update kubernetes service claim status: %w", err)
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+// boundKubernetesService returns the KubernetesService bound to a claim, or
+// nil if there is none.
+func (r *ClaimReconciler) boundKubernetesService(ctx context.Context, claim *hyperlitev1.KubernetesServiceClaim) (*hyperlitev1.KubernetesService, error) {
+	kubeServices := &hyperlitev1.KubernetesServiceList{}
+	if err := r.Reader.List(ctx, kubeServices, client.InNamespace(poolNamespace(claim)), client.MatchingLabels{PoolLabel: claim.Spec.Pool}); err != nil {
+		return nil, fmt.Errorf("failed to list kubernetes services of pool: %w", err)
+	}
+	for i := range kubeServices.Items {
+		if key, ok := claimKey(&kubeServices.Items[i]); ok && key == client.ObjectKeyFromObject(claim) {
+			return &kubeServices.Items[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// bind binds a KubernetesService of the pool of a claim to the claim,
+// preferring available ones and ones created from the current template. If
+// none can be bound, it returns the reason and a message.
+func (r *ClaimReconciler) bind(ctx context.Context, claim *hyperlitev1.KubernetesServiceClaim) (*hyperlitev1.KubernetesService, string, string, error) {
+	pool := &hyperlitev1.KubernetesServicePool{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: poolNamespace(claim), Name: claim.Spec.Pool}, pool); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, "PoolNotFound", fmt.Sprintf("KubernetesServicePool %s/%s not found", poolNamespace(claim), claim.Spec.Pool), nil
+		}
+		return nil, "", "", fmt.Errorf("cannot get kubernetes service pool: %w", err)
+	}
+	if pool.Namespace != claim.Namespace && !sets.NewString(pool.Spec.ClaimNamespaces...).Has(claim.Namespace) {
+		return nil, "ClaimNotAllowed", fmt.Sprintf("KubernetesServicePool %s/%s does not allow claims from namespace %s", pool.Namespace, pool.Name, claim.Namespace), nil
+	}
+	if !pool.DeletionTimestamp.IsZero() {
+		return nil, "PoolDeleting", fmt.Sprintf("KubernetesServicePool %s/%s is being deleted", pool.Namespace, pool.Name), nil
+	}
+
+	hash, err := templateHash(&pool.Spec.Template)
+	if err != nil {
+		return nil, "", "", err
+	}
+	current, stale, err := unclaimedKubernetesServices(ctx, r.Reader, pool, hash)
+	if err != nil {
+		return nil, "", "", err
+	}
+	var candidates []*hyperlitev1.KubernetesService
+	for _, available := range []bool{true, false} {
+		for _, kubeSvc := range append(current, stale...) {
+			if isAvailable(kubeSvc) == available {
+				candidates = append(candidates, kubeSvc)
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, "NoKubernetesServices", fmt.Sprintf("Waiting for KubernetesServicePool %s/%s to create a KubernetesService", pool.Namespace, pool.Name), nil
+	}
+
+	// Updating the KubernetesService fails if another claim bound it first
+	kubeSvc := candidates[0]
+	if kubeSvc.Annotations == nil {
+		kubeSvc.Annotations = map[string]string{}
+	}
+	kubeSvc.Annotations[ClaimAnnotation] = claim.Namespace + "/" + claim.Name
+	kubeSvc.SetOwnerReferences(removeOwnerRef(kubeSvc.OwnerReferences, pool.UID))
+	if claim.Spec.Lifetime != nil {
+		expiresAt := metav1.NewTime(time.Now().Add(claim.Spec.Lifetime.Duration))
+		kubeSvc.Spec.Lifecycle.Lifetime = nil
+		kubeSvc.Spec.Lifecycle.ExpiresAt = &expiresAt
+	}
+	if err := r.Update(ctx, kubeSvc); err != nil {
+		return nil, "", "", fmt.Errorf("failed to bind kubernetes service %s: %w", kubeSvc.Name, err)
+	}
+	ctrl.LoggerFrom(ctx).Info("Bound KubernetesService", "name", kubeSvc.Name)
+	r.recorder.Eventf(claim, corev1.EventTypeNormal, "Bound", "Bound to KubernetesService %s/%s", kubeSvc.Namespace, kubeSvc.Name)
+	return kubeSvc, "", "", nil
+}
+
+// reconcileKubeconfigs exposes system:admin kubeconfigs of the KubernetesService
+// bound to a claim in the namespace of the claim. The service kubeconfig is
+// generated once the root CA of the control plane exists, and the external
+// kubeconfig is copied while the API server is published.
+func (r *ClaimReconciler) reconcileKubeconfigs(ctx context.Context, claim *hyperlitev1.KubernetesServiceClaim, kubeSvc *hyperlitev1.KubernetesService) (hyperlitev1.KubeconfigSecrets, error) {
+	var kubeconfigs hyperlitev1.KubeconfigSecrets
+	namespace, instance := ks.ControlPlaneNamespace(kubeSvc), ks.InstanceName(kubeSvc)
+
+	rootCASecret := pki.RootCASecret(namespace, instance)
+	if err := r.Get(ctx, client.ObjectKeyFromObject(rootCASecret), rootCASecret); err != nil && !apierrors.IsNotFound(err) {
+		return kubeconfigs, fmt.Errorf("cannot get root CA secret: %w", err)
+	} else if err == nil && kubeSvc.Status.InternalAPIEndpoint != nil {
+		serviceKubeconfigSecret := kubeconfigSecret(claim, "admin-kubeconfig")
+		if _, err := controllerutil.CreateOrUpdate(ctx, r, serviceKubeconfigSecret, func() error {
+			ensureClaimOwnerRef(claim, serviceKubeconfigSecret)
+			return kas.ReconcileExportedServiceKubeconfigSecret(serviceKubeconfigSecret, rootCASecret, kas.Service(namespace, instance), int(kubeSvc.Status.InternalAPIEndpoint.Port))
+		}); err != nil {
+			return kubeconfigs, fmt.Errorf("failed to reconcile claim kubeconfig secret: %w", err)
+		}
+		kubeconfigs.Service = &corev1.LocalObjectReference{Name: serviceKubeconfigSecret.Name}
+	}
+
+	externalKubeconfigSecret := kubeconfigSecret(claim, "admin-external-kubeconfig")
+	if external := kubeSvc.Status.Kubeconfigs.External; external != nil {
+		source := &corev1.Secret{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: kubeSvc.Namespace, Name: external.Name}, source); err != nil {
+			return kubeconfigs, fmt.Errorf("cannot get external kubeconfig secret: %w", err)
+		}
+		if _, err := controllerutil.CreateOrUpdate(ctx, r, externalKubeconfigSecret, func() error {
+			ensureClaimOwnerRef(claim, externalKubeconfigSecret)
+			externalKubeconfigSecret.Type = source.Type
+			externalKubeconfigSecret.Data = source.Data
+			return nil
+		}); err != nil {
+			return kubeconfigs, fmt.Errorf("failed to reconcile claim external kubeconfig secret: %w", err)
+		}
+		kubeconfigs.External = &corev1.LocalObjectReference{Name: externalKubeconfigSecret.Name}
+	} else if err := r.Delete(ctx, externalKubeconfigSecret); err != nil && !apierrors.IsNotFound(err) {
+		return kubeconfigs, fmt.Errorf("failed to remove claim external kubeconfig secret: %w", err)
+	}
+	return kubeconfigs, nil
+}
+
+func kubeconfigSecret(claim *hyperlitev1.KubernetesServiceClaim, name string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ks.Name(claim.Name, name),
+			Namespace: claim.Namespace,
+		},
+	}
+}
+
+func ensureClaimOwnerRef(claim *hyperlitev1.KubernetesServiceClaim, object client.Object) {
+	for _, ref := range object.GetOwnerReferences() {
+		if ref.UID == claim.UID {
+			return
+		}
+	}
+	object.SetOwnerReferences(append(object.GetOwnerReferences(), metav1.OwnerReference{
+		APIVersion:         hyperlitev1.GroupVersion.String(),
+		Kind:               "KubernetesServiceClaim",
+		Name:               claim.Name,
+		UID:                claim.UID,
+		Controller:         pointer.BoolPtr(true),
+		BlockOwnerDeletion: pointer.BoolPtr(true),
+	}))
+}
+
+func removeOwnerRef(ownerRefs []metav1.OwnerReference, uid types.UID) []metav1.OwnerReference {
+	var refs []metav1.OwnerReference
+	for _, ref := range ownerRefs {
+		if ref.UID != uid {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// claimKey returns the claim a KubernetesService is bound to.
+func claimKey(kubeSvc client.Object) (client.ObjectKey, bool) {
+	value, ok := kubeSvc.GetAnnotations()[ClaimAnnotation]
+	if !ok {
+		return client.ObjectKey{}, false
+	}
+	parts := strings.SplitN(value, "/", 2)
+	if len(parts) != 2 {
+		return client.ObjectKey{}, false
+	}
+	return client.ObjectKey{Namespace: parts[0], Name: parts[1]}, true
+}
+
+// poolNamespace returns the namespace of the pool of a claim.
+func poolNamespace(claim *hyperlitev1.KubernetesServiceClaim) string {
+	if claim.Spec.PoolNamespace != "" {
+		return claim.Spec.PoolNamespace
+	}
+	return claim.Namespace
+}
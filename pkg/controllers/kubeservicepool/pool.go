@@ -0,0 +1,233 @@
+package kubeservicepool
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
+	"github.com/openshift-hive/hypershiftlite/pkg/controllers/kubeservice/ks"
+)
+
+const (
+	// PoolLabel is set on the KubernetesServices of a pool to the name of the
+	// pool.
+	PoolLabel = "hypershiftlite.openshift.io/pool"
+
+	// ClaimAnnotation is set on a KubernetesService of a pool to the
+	// <namespace>/<name> of the claim it is bound to.
+	ClaimAnnotation = "hypershiftlite.openshift.io/claim"
+
+	// templateHashAnnotation records the hash of the pool template a
+	// KubernetesService was created from.
+	templateHashAnnotation = "hypershiftlite.openshift.io/pool-template-hash"
+)
+
+// PoolReconciler keeps the number of unclaimed KubernetesServices of each
+// KubernetesServicePool at its size.
+type PoolReconciler struct {
+	client.Client
+	// Reader lists objects directly from the API server, so that
+	// KubernetesServices just created or claimed are not missed.
+	Reader client.Reader
+}
+
+func (r *PoolReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	_, err := ctrl.NewControllerManagedBy(mgr).
+		For(&hyperlitev1.KubernetesServicePool{}).
+		Watches(&source.Kind{Type: &hyperlitev1.KubernetesService{}}, handler.EnqueueRequestsFromMapFunc(poolForKubernetesService)).
+		Build(r)
+	if err != nil {
+		return fmt.Errorf("failed setting up with a controller manager %w", err)
+	}
+	return nil
+}
+
+// poolForKubernetesService maps a KubernetesService to the pool it was
+// created by, so that the pool is replenished when it is claimed or removed.
+func poolForKubernetesService(obj client.Object) []reconcile.Request {
+	pool, ok := obj.GetLabels()[PoolLabel]
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: client.ObjectKey{Namespace: obj.GetNamespace(), Name: pool}}}
+}
+
+func (r *PoolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx).WithValues("kubeServicePool", req.NamespacedName.String())
+
+	pool := &hyperlitev1.KubernetesServicePool{}
+	if err := r.Get(ctx, req.NamespacedName, pool); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if !pool.DeletionTimestamp.IsZero() {
+		// Unclaimed KubernetesServices are garbage collected with the pool
+		return ctrl.Result{}, nil
+	}
+
+	hash, err := templateHash(&pool.Spec.Template)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	current, stale, err := unclaimedKubernetesServices(ctx, r.Reader, pool, hash)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	size := int(pool.Spec.Size)
+
+	// Replace claimed and removed KubernetesServices
+	for i := len(current); i < size; i++ {
+		kubeSvc := newPoolKubernetesService(pool, hash)
+		if err := r.Create(ctx, kubeSvc); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to create kubernetes service: %w", err)
+		}
+		log.Info("Created pool KubernetesService", "name", kubeSvc.Name)
+		current = append(current, kubeSvc)
+	}
+
+	// Remove surplus KubernetesServices when the pool shrinks, unavailable
+	// ones first
+	if len(current) > size {
+		for _, kubeSvc := range current[size:] {
+			if err := r.deleteKubernetesService(ctx, kubeSvc); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+		current = current[:size]
+	}
+
+	// Remove KubernetesServices of an earlier template once they can be
+	// replaced by available ones of the current template. Unavailable ones
+	// are of no use in the meantime.
+	var kept []*hyperlitev1.KubernetesService
+	replaced := countAvailable(current) >= size
+	for _, kubeSvc := range stale {
+		if !replaced && isAvailable(kubeSvc) {
+			kept = append(kept, kubeSvc)
+			continue
+		}
+		if err := r.deleteKubernetesService(ctx, kubeSvc); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	kept = append(kept, current...)
+
+	status := hyperlitev1.KubernetesServicePoolStatus{
+		Size:  int32(len(kept)),
+		Ready: int32(countAvailable(kept)),
+	}
+	if pool.Status != status {
+		pool.Status = status
+		if err := r.Status().Update(ctx, pool); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update kubernetes service pool status: %w", err)
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+// unclaimedKubernetesServices returns the unclaimed KubernetesServices of a
+// pool created from the template with the given hash and those created from
+// earlier templates, available ones first and oldest first.
+func unclaimedKubernetesServices(ctx context.Context, c client.Reader, pool *hyperlitev1.KubernetesServicePool, hash string) ([]*hyperlitev1.KubernetesService, []*hyperlitev1.KubernetesService, error) {
+	kubeServices := &hyperlitev1.KubernetesServiceList{}
+	if err := c.List(ctx, kubeServices, client.InNamespace(pool.Namespace), client.MatchingLabels{PoolLabel: pool.Name}); err != nil {
+		return nil, nil, fmt.Errorf("failed to list kubernetes services of pool: %w", err)
+	}
+	var current, stale []*hyperlitev1.KubernetesService
+	for i := range kubeServices.Items {
+		kubeSvc := &kubeServices.Items[i]
+		if _, claimed := kubeSvc.Annotations[ClaimAnnotation]; claimed || !kubeSvc.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if kubeSvc.Annotations[templateHashAnnotation] == hash {
+			current = append(current, kubeSvc)
+		} else {
+			stale = append(stale, kubeSvc)
+		}
+	}
+	for _, kubeSvcs := range [][]*hyperlitev1.KubernetesService{current, stale} {
+		sort.SliceStable(kubeSvcs, func(i, j int) bool {
+			if isAvailable(kubeSvcs[i]) != isAvailable(kubeSvcs[j]) {
+				return isAvailable(kubeSvcs[i])
+			}
+			return kubeSvcs[i].CreationTimestamp.Before(&kubeSvcs[j].CreationTimestamp)
+		})
+	}
+	return current, stale, nil
+}
+
+// newPoolKubernetesService returns a new KubernetesService of a pool.
+func newPoolKubernetesService(pool *hyperlitev1.KubernetesServicePool, hash string) *hyperlitev1.KubernetesService {
+	return &hyperlitev1.KubernetesService{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: pool.Name + "-",
+			Namespace:    pool.Namespace,
+			Labels: map[string]string{
+				PoolLabel: pool.Name,
+			},
+			Annotations: map[string]string{
+				templateHashAnnotation: hash,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion:         hyperlitev1.GroupVersion.String(),
+					Kind:               "KubernetesServicePool",
+					Name:               pool.Name,
+					UID:                pool.UID,
+					BlockOwnerDeletion: pointer.BoolPtr(true),
+				},
+			},
+		},
+		Spec: *pool.Spec.Template.DeepCopy(),
+	}
+}
+
+func (r *PoolReconciler) deleteKubernetesService(ctx context.Context, kubeSvc *hyperlitev1.KubernetesService) error {
+	ctrl.LoggerFrom(ctx).Info("Deleting pool KubernetesService", "name", kubeSvc.Name)
+	if err := r.Delete(ctx, kubeSvc); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete kubernetes service %s: %w", kubeSvc.Name, err)
+	}
+	return nil
+}
+
+// templateHash returns a hash of a pool template, to tell KubernetesServices
+// created from earlier templates apart.
+func templateHash(template *hyperlitev1.KubernetesServiceSpec) (string, error) {
+	b, err := json.Marshal(template)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize pool template: %w", err)
+	}
+	h := fnv.New64a()
+	h.Write(b)
+	return fmt.Sprintf("%x", h.Sum64()), nil
+}
+
+func isAvailable(kubeSvc *hyperlitev1.KubernetesService) bool {
+	condition := ks.GetConditionByType(kubeSvc.Status.Conditions, hyperlitev1.Available)
+	return condition != nil && condition.Status == corev1.ConditionTrue
+}
+
+func countAvailable(kubeSvcs []*hyperlitev1.KubernetesService) int {
+	var n int
+	for _, kubeSvc := range kubeSvcs {
+		if isAvailable(kubeSvc) {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,119 @@
+package storageversion
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	hyperlitev1 "github.com/openshift-hive/hypershiftlite/pkg/api/v1beta1"
+)
+
+const (
+	kubernetesServiceCRDName = "kubernetesservice.hypershiftlite.openshift.io"
+	migrationPageSize        = 100
+)
+
+// Migrator rewrites every KubernetesService in the storage version of the CRD
+// and then drops older versions from the stored versions of the CRD, so that
+// those versions can stop being served. It runs once when the operator starts.
+// Objects that are rejected when written back, for example by validation, are
+// skipped and reported in a warning event, and the older versions are kept in
+// the stored versions until they are fixed.
+type Migrator struct {
+	Client client.Client
+	// Reader lists objects directly from the API server.
+	Reader client.Reader
+
+	recorder record.EventRecorder
+}
+
+func (m *Migrator) SetupWithManager(mgr ctrl.Manager) error {
+	m.recorder = mgr.GetEventRecorderFor("storage-version-migrator")
+	if err := mgr.Add(m); err != nil {
+		return fmt.Errorf("failed setting up with a controller manager %w", err)
+	}
+	return nil
+}
+
+// Start migrates the stored KubernetesServices, retrying until it succeeds or
+// the context is done. Objects are read through the conversion webhook, which
+// may not be serving yet when the migrator starts.
+func (m *Migrator) Start(ctx context.Context) error {
+	log := ctrl.Log.WithName("storage-version-migrator")
+	return wait.PollImmediateUntil(10*time.Second, func() (bool, error) {
+		if err := m.migrate(ctx); err != nil {
+			log.Error(err, "storage version migration failed, retrying")
+			return false, nil
+		}
+		return true, nil
+	}, ctx.Done())
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable so that only
+// one operator instance migrates objects.
+func (m *Migrator) NeedLeaderElection() bool {
+	return true
+}
+
+func (m *Migrator) migrate(ctx context.Context) error {
+	log := ctrl.Log.WithName("storage-version-migrator")
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	if err := m.Client.Get(ctx, types.NamespacedName{Name: kubernetesServiceCRDName}, crd); err != nil {
+		return fmt.Errorf("cannot get KubernetesService CRD: %w", err)
+	}
+	storageVersion := hyperlitev1.GroupVersion.Version
+	if len(crd.Status.StoredVersions) == 1 && crd.Status.StoredVersions[0] == storageVersion {
+		return nil
+	}
+
+	log.Info("Migrating KubernetesServices to the storage version", "storedVersions", crd.Status.StoredVersions, "storageVersion", storageVersion)
+	var rejected []string
+	opts := &client.ListOptions{Limit: migrationPageSize}
+	for {
+		kubeServices := &hyperlitev1.KubernetesServiceList{}
+		if err := m.Reader.List(ctx, kubeServices, opts); err != nil {
+			return fmt.Errorf("failed to list KubernetesServices: %w", err)
+		}
+		for i := range kubeServices.Items {
+			kubeSvc := &kubeServices.Items[i]
+			// An unchanged update is written back in the storage version.
+			// Objects that changed or went away since they were listed have
+			// already been written in it.
+			err := m.Client.Update(ctx, kubeSvc)
+			switch {
+			case err == nil, apierrors.IsConflict(err), apierrors.IsNotFound(err):
+			case apierrors.IsInvalid(err), apierrors.IsForbidden(err):
+				// Other objects are still migrated, so that only the
+				// rejected ones need fixing
+				log.Info("KubernetesService rejected by storage version migration", "kubeService", client.ObjectKeyFromObject(kubeSvc).String(), "error", err.Error())
+				m.recorder.Eventf(kubeSvc, corev1.EventTypeWarning, "StorageVersionMigrationFailed", "Cannot be rewritten in version %s, fix it so that older versions can stop being served: %v", storageVersion, err)
+				rejected = append(rejected, client.ObjectKeyFromObject(kubeSvc).String())
+			default:
+				return fmt.Errorf("failed to migrate KubernetesService %s/%s: %w", kubeSvc.Namespace, kubeSvc.Name, err)
+			}
+		}
+		if kubeServices.Continue == "" {
+			break
+		}
+		opts.Continue = kubeServices.Continue
+	}
+	if len(rejected) > 0 {
+		return fmt.Errorf("%d KubernetesServices were rejected and keep %v in the stored versions: %v", len(rejected), crd.Status.StoredVersions, rejected)
+	}
+
+	crd.Status.StoredVersions = []string{storageVersion}
+	if err := m.Client.Status().Update(ctx, crd); err != nil {
+		return fmt.Errorf("failed to update stored versions of KubernetesService CRD: %w", err)
+	}
+	log.Info("KubernetesServices migrated to the storage version")
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"strings"
 	"time"
 
 	imageapi "github.com/openshift/api/image/v1"
@@ -60,6 +61,7 @@ func (p *PodProvider) Lookup(ctx context.Context, image, pullSecretName string)
 	}()
 
 	// Wait for the pod to reach a terminate state
+	var imageID string
 	err = wait.PollImmediateUntil(1*time.Second, func() (bool, error) {
 		pod, err := p.Pods.Get(ctx, pod.Name, metav1.GetOptions{})
 		if err != nil {
@@ -67,6 +69,9 @@ func (p *PodProvider) Lookup(ctx context.Context, image, pullSecretName string)
 		}
 		switch pod.Status.Phase {
 		case corev1.PodSucceeded:
+			if len(pod.Status.ContainerStatuses) > 0 {
+				imageID = pod.Status.ContainerStatuses[0].ImageID
+			}
 			return true, nil
 		case corev1.PodFailed:
 			return true, fmt.Errorf("image lookup pod failed")
@@ -98,6 +103,17 @@ func (p *PodProvider) Lookup(ctx context.Context, image, pullSecretName string)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't read image lookup pod %q logs as a serialized ImageStream: %w\nraw logs:\n%s", pod.Name, err, string(data))
 	}
-	releaseImage = &ReleaseImage{ImageStream: &imageStream}
+	releaseImage = &ReleaseImage{ImageStream: &imageStream, Digest: imageDigest(image, imageID)}
 	return
 }
+
+// imageDigest returns the digest of an image, taken from the image ID reported
+// by the container runtime or from the pull spec if it references a digest.
+func imageDigest(image, imageID string) string {
+	for _, ref := range []string{imageID, image} {
+		if i := strings.LastIndex(ref, "@"); i >= 0 {
+			return ref[i+1:]
+		}
+	}
+	return ""
+}
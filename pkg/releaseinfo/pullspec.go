@@ -0,0 +1,30 @@
+package releaseinfo
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// pullSpecRegexp matches image references following the grammar of the
+// container image reference format: an optional registry host and port,
+// a repository path of lowercase components, and an optional tag and digest.
+var pullSpecRegexp = func() *regexp.Regexp {
+	const (
+		domainComponent = `(?:[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9])`
+		domain          = domainComponent + `(?:\.` + domainComponent + `)*(?::[0-9]+)?`
+		pathComponent   = `[a-z0-9]+(?:(?:[._]|__|[-]*)[a-z0-9]+)*`
+		name            = `(?:` + domain + `/)?` + pathComponent + `(?:/` + pathComponent + `)*`
+		tag             = `[\w][\w.-]{0,127}`
+		digest          = `[A-Za-z][A-Za-z0-9]*(?:[-_+.][A-Za-z][A-Za-z0-9]*)*:[0-9a-fA-F]{32,}`
+	)
+	return regexp.MustCompile(`^` + name + `(?::` + tag + `)?(?:@` + digest + `)?$`)
+}()
+
+// ValidatePullSpec verifies that an image pull spec is a well formed image
+// reference.
+func ValidatePullSpec(image string) error {
+	if !pullSpecRegexp.MatchString(image) {
+		return fmt.Errorf("invalid image pull spec %q", image)
+	}
+	return nil
+}
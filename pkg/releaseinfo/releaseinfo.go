@@ -24,6 +24,9 @@ type Provider interface {
 // discover constituent component image information.
 type ReleaseImage struct {
 	*imageapi.ImageStream
+
+	// Digest is the digest of the release image, if known
+	Digest string
 }
 
 func (i *ReleaseImage) Version() string {